@@ -0,0 +1,121 @@
+package inertia
+
+import (
+	"sync"
+	"time"
+)
+
+// BreakerState type.
+type BreakerState string
+
+const (
+	// BreakerClosed state.
+	BreakerClosed BreakerState = "closed"
+
+	// BreakerOpen state.
+	BreakerOpen BreakerState = "open"
+
+	// BreakerHalfOpen state.
+	BreakerHalfOpen BreakerState = "half-open"
+)
+
+const defaultBreakerCooldown = 30 * time.Second
+
+type ssrBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+func newSsrBreaker(options SsrOptions) *ssrBreaker {
+	if options.BreakerThreshold <= 0 {
+		return nil
+	}
+
+	cooldown := options.BreakerCooldown
+	if cooldown <= 0 {
+		cooldown = defaultBreakerCooldown
+	}
+
+	return &ssrBreaker{
+		threshold: options.BreakerThreshold,
+		cooldown:  cooldown,
+	}
+}
+
+func (b *ssrBreaker) allow() bool {
+	if b == nil {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return true
+	}
+
+	if b.probing || time.Now().Before(b.openUntil) {
+		return false
+	}
+
+	b.probing = true
+
+	return true
+}
+
+func (b *ssrBreaker) record(err error) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+
+	if err == nil {
+		b.failures = 0
+
+		return
+	}
+
+	b.failures++
+
+	if b.failures >= b.threshold {
+		b.openUntil = time.Now().Add(b.cooldown)
+	}
+}
+
+func (b *ssrBreaker) release() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+func (b *ssrBreaker) state() BreakerState {
+	if b == nil {
+		return BreakerClosed
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return BreakerClosed
+	}
+
+	if !b.probing && time.Now().Before(b.openUntil) {
+		return BreakerOpen
+	}
+
+	return BreakerHalfOpen
+}
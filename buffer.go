@@ -0,0 +1,38 @@
+package inertia
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+const maxPooledBufferSize = 1 << 20
+
+var bufferPool = sync.Pool{
+	New: func() any {
+		return new(bytes.Buffer)
+	},
+}
+
+func getBuffer() *bytes.Buffer {
+	return bufferPool.Get().(*bytes.Buffer)
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBufferSize {
+		return
+	}
+
+	buf.Reset()
+	bufferPool.Put(buf)
+}
+
+func writeBuffer(w http.ResponseWriter, contentType string, buf *bytes.Buffer) error {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+
+	_, err := buf.WriteTo(w)
+
+	return err
+}
@@ -0,0 +1,156 @@
+package inertia
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// SsrCache type.
+type SsrCache interface {
+	Get(key string) (*Ssr, bool)
+	Set(key string, ssr *Ssr)
+	Clear()
+}
+
+// MemorySsrCache type.
+type MemorySsrCache struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type memorySsrCacheEntry struct {
+	key       string
+	ssr       *Ssr
+	expiresAt time.Time
+}
+
+// NewMemorySsrCache function.
+func NewMemorySsrCache(size int, ttl time.Duration) *MemorySsrCache {
+	return &MemorySsrCache{
+		size:    size,
+		ttl:     ttl,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// Get function.
+func (c *MemorySsrCache) Get(key string) (*Ssr, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := el.Value.(*memorySsrCacheEntry)
+
+	if c.ttl > 0 && time.Now().After(entry.expiresAt) {
+		c.remove(el)
+
+		return nil, false
+	}
+
+	c.order.MoveToFront(el)
+
+	return entry.ssr, true
+}
+
+// Set function.
+func (c *MemorySsrCache) Set(key string, ssr *Ssr) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := time.Now().Add(c.ttl)
+
+	if el, ok := c.entries[key]; ok {
+		entry := el.Value.(*memorySsrCacheEntry)
+		entry.ssr = ssr
+		entry.expiresAt = expiresAt
+
+		c.order.MoveToFront(el)
+
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&memorySsrCacheEntry{
+		key:       key,
+		ssr:       ssr,
+		expiresAt: expiresAt,
+	})
+
+	for c.size > 0 && c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+}
+
+// Clear function.
+func (c *MemorySsrCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.entries)
+	c.order.Init()
+}
+
+// Len function.
+func (c *MemorySsrCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *MemorySsrCache) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.entries, el.Value.(*memorySsrCacheEntry).key)
+}
+
+type ssrCache struct {
+	mu      sync.Mutex
+	cache   SsrCache
+	version string
+}
+
+func (c *ssrCache) get(page *Page) (string, *Ssr, bool) {
+	if c == nil {
+		return "", nil, false
+	}
+
+	body, err := json.Marshal(page)
+	if err != nil {
+		return "", nil, false
+	}
+
+	c.mu.Lock()
+
+	if c.version != page.Version {
+		c.version = page.Version
+		c.cache.Clear()
+	}
+
+	c.mu.Unlock()
+
+	sum := sha256.Sum256(body)
+	key := hex.EncodeToString(sum[:])
+
+	ssr, ok := c.cache.Get(key)
+
+	return key, ssr, ok
+}
+
+func (c *ssrCache) set(key string, ssr *Ssr) {
+	if c == nil || key == "" {
+		return
+	}
+
+	c.cache.Set(key, ssr)
+}
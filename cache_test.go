@@ -0,0 +1,130 @@
+package inertia
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMemorySsrCache(t *testing.T) {
+	c := NewMemorySsrCache(2, 0)
+
+	c.Set("a", &Ssr{Body: "a"})
+	c.Set("b", &Ssr{Body: "b"})
+
+	if _, ok := c.Get("a"); !ok {
+		t.Error("expected a to be cached")
+	}
+
+	c.Set("c", &Ssr{Body: "c"})
+
+	if _, ok := c.Get("b"); ok {
+		t.Error("expected b to be evicted as least recently used")
+	}
+
+	ssr, ok := c.Get("c")
+	if !ok || ssr.Body != "c" {
+		t.Errorf("expected: c, got: %v", ssr)
+	}
+
+	if c.Len() != 2 {
+		t.Errorf("expected 2 entries, got: %d", c.Len())
+	}
+
+	c.Clear()
+
+	if c.Len() != 0 {
+		t.Errorf("expected empty cache, got: %d", c.Len())
+	}
+}
+
+func TestMemorySsrCacheTTL(t *testing.T) {
+	c := NewMemorySsrCache(0, 10*time.Millisecond)
+
+	c.Set("a", &Ssr{Body: "a"})
+
+	if _, ok := c.Get("a"); !ok {
+		t.Error("expected a to be cached")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if _, ok := c.Get("a"); ok {
+		t.Error("expected a to be expired")
+	}
+
+	if c.Len() != 0 {
+		t.Errorf("expected empty cache, got: %d", c.Len())
+	}
+}
+
+func TestRenderWithSsrCache(t *testing.T) {
+	var calls atomic.Int32
+
+	ts := newSsrTestServer(t, func() {
+		calls.Add(1)
+	})
+
+	version := "1"
+
+	i := newSsrTestInertia(ts.URL, ssrTestTemplate)
+	i.SetVersionFunc(func(*http.Request) string {
+		return version
+	})
+
+	cache := NewMemorySsrCache(10, time.Minute)
+	i.EnableSsrCache(cache)
+
+	render := func(props map[string]any) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := httptest.NewRecorder()
+
+		err := i.Render(w, r, "test/component", props)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if w.Body.String() != `<div id="app"></div>` {
+			t.Errorf("unexpected body: %s", w.Body.String())
+		}
+	}
+
+	render(nil)
+	render(nil)
+
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 ssr call, got: %d", calls.Load())
+	}
+
+	render(map[string]any{"user": "alice"})
+
+	if calls.Load() != 2 {
+		t.Errorf("expected 2 ssr calls, got: %d", calls.Load())
+	}
+
+	if cache.Len() != 2 {
+		t.Errorf("expected 2 entries, got: %d", cache.Len())
+	}
+
+	version = "2"
+
+	render(nil)
+
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 ssr calls, got: %d", calls.Load())
+	}
+
+	if cache.Len() != 1 {
+		t.Errorf("expected the cache to be cleared on version change, got: %d entries", cache.Len())
+	}
+
+	i.DisableSsrCache()
+
+	render(nil)
+
+	if calls.Load() != 4 {
+		t.Errorf("expected 4 ssr calls, got: %d", calls.Load())
+	}
+}
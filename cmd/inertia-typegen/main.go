@@ -0,0 +1,58 @@
+// Command inertia-typegen generates TypeScript declarations for the page
+// props of the components registered with inertia.NewComponent.
+//
+// Usage:
+//
+//	inertia-typegen [-out file] [dir ...]
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/petaki/inertia-go/typegen"
+)
+
+func main() {
+	out := flag.String("out", "", "output file, defaults to stdout")
+	flag.Parse()
+
+	dirs := flag.Args()
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+
+	err := run(*out, dirs)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "inertia-typegen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(out string, dirs []string) error {
+	g := typegen.New()
+
+	for _, dir := range dirs {
+		err := g.LoadPackage(dir)
+		if err != nil {
+			return err
+		}
+	}
+
+	var b bytes.Buffer
+
+	err := g.Write(&b)
+	if err != nil {
+		return err
+	}
+
+	if out == "" {
+		_, err = os.Stdout.Write(b.Bytes())
+
+		return err
+	}
+
+	return os.WriteFile(out, b.Bytes(), 0o644)
+}
@@ -0,0 +1,48 @@
+package inertia
+
+import (
+	"maps"
+	"net/http"
+	"reflect"
+)
+
+// Component type.
+type Component[P any] struct {
+	inertia *Inertia
+	name    string
+}
+
+// NewComponent function.
+func NewComponent[P any](i *Inertia, name string) *Component[P] {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.components[name] = reflect.TypeFor[P]()
+
+	return &Component[P]{
+		inertia: i,
+		name:    name,
+	}
+}
+
+// Name function.
+func (c *Component[P]) Name() string {
+	return c.name
+}
+
+// Render function.
+func (c *Component[P]) Render(w http.ResponseWriter, r *http.Request, props P) error {
+	if m, ok := any(props).(map[string]any); ok {
+		return c.inertia.Render(w, r, c.name, m)
+	}
+
+	return c.inertia.RenderStruct(w, r, c.name, props)
+}
+
+// Components function.
+func (i *Inertia) Components() map[string]reflect.Type {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return maps.Clone(i.components)
+}
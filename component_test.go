@@ -0,0 +1,77 @@
+package inertia
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+type testUsersIndexProps struct {
+	Title string                                  `json:"title"`
+	Users func(context.Context) ([]string, error) `inertia:"users,deferred"`
+}
+
+func TestNewComponent(t *testing.T) {
+	i := New("http://inertia-go.test", "", "")
+	users := NewComponent[testUsersIndexProps](i, "Users/Index")
+
+	if users.Name() != "Users/Index" {
+		t.Errorf("expected: Users/Index, got: %s", users.Name())
+	}
+
+	components := i.Components()
+
+	if components["Users/Index"] != reflect.TypeFor[testUsersIndexProps]() {
+		t.Errorf("expected: testUsersIndexProps, got: %v", components["Users/Index"])
+	}
+}
+
+func TestComponentRender(t *testing.T) {
+	i := New("http://inertia-go.test", "", "")
+	i.Share("appName", "Inertia")
+	users := NewComponent[testUsersIndexProps](i, "Users/Index")
+
+	r := httptest.NewRequest(http.MethodGet, "/users", nil)
+	r.Header.Set(HeaderInertia, "true")
+
+	page := renderTestPage(t, r, func(w http.ResponseWriter, r *http.Request) error {
+		return users.Render(w, r, testUsersIndexProps{
+			Title: "Users",
+			Users: func(context.Context) ([]string, error) { return []string{"alice"}, nil },
+		})
+	})
+
+	if page.Component != "Users/Index" {
+		t.Errorf("expected: Users/Index, got: %s", page.Component)
+	}
+
+	if page.Props["title"] != "Users" {
+		t.Errorf("expected: Users, got: %v", page.Props["title"])
+	}
+
+	if page.Props["appName"] != "Inertia" {
+		t.Errorf("expected: Inertia, got: %v", page.Props["appName"])
+	}
+
+	if len(page.DeferredProps["default"]) != 1 || page.DeferredProps["default"][0] != "users" {
+		t.Errorf("expected deferred default group [users], got: %v", page.DeferredProps["default"])
+	}
+}
+
+func TestComponentRenderWithMap(t *testing.T) {
+	i := New("http://inertia-go.test", "", "")
+	home := NewComponent[map[string]any](i, "Home")
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(HeaderInertia, "true")
+
+	page := renderTestPage(t, r, func(w http.ResponseWriter, r *http.Request) error {
+		return home.Render(w, r, map[string]any{"total": 32})
+	})
+
+	if page.Props["total"] != float64(32) {
+		t.Errorf("expected: 32, got: %v", page.Props["total"])
+	}
+}
@@ -0,0 +1,45 @@
+package inertia
+
+import (
+	"html/template"
+	"io/fs"
+	"sync"
+	"sync/atomic"
+)
+
+// config is an immutable snapshot of the shared configuration. Writers
+// publish a modified copy, so Render never has to hold the lock while it
+// waits on SSR or executes the root template.
+type config struct {
+	url            string
+	rootTemplate   string
+	version        string
+	versionFunc    VersionFunc
+	sharedProps    map[string]any
+	sharedFuncMap  template.FuncMap
+	sharedViewData map[string]any
+	parsedTemplate *parsedTemplate
+	templateFS     fs.FS
+	ssrRenderer    SsrRenderer
+	ssrOptions     SsrOptions
+	ssrBreaker     *ssrBreaker
+	ssrCache       *ssrCache
+	ssrFallback    SsrErrorHandler
+	ssrExcludes    []string
+	sessionStore   SessionStore
+	sessionError   SessionErrorHandler
+	earlyHints     func(*Page) ([]string, error)
+	concurrent     bool
+	concurrency    int
+}
+
+type parsedTemplate struct {
+	mu       sync.Mutex
+	template atomic.Pointer[template.Template]
+}
+
+func (i *Inertia) snapshot() *config {
+	c := *i.config.Load()
+
+	return &c
+}
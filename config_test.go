@@ -0,0 +1,170 @@
+package inertia
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func newSsrTestServer(t testing.TB, handler func()) *httptest.Server {
+	t.Helper()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"head":[],"body":"<div id=\"app\"></div>"}`))
+	}))
+
+	t.Cleanup(ts.Close)
+
+	return ts
+}
+
+const ssrTestTemplate = `{{ if .ssr }}{{ raw .ssr.Body }}{{ end }}`
+
+func newSsrTestInertia(ssrURL, rootTemplate string) *Inertia {
+	templateFS := fstest.MapFS{
+		"app.gohtml": {Data: []byte(rootTemplate)},
+	}
+
+	i := New("http://inertia-go.test", "app.gohtml", "", templateFS)
+	i.EnableSsr(ssrURL)
+
+	return i
+}
+
+func TestShareDuringSsrRender(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	ts := newSsrTestServer(t, func() {
+		close(started)
+		<-release
+	})
+
+	i := newSsrTestInertia(ts.URL, ssrTestTemplate)
+
+	done := make(chan error)
+
+	go func() {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := httptest.NewRecorder()
+
+		done <- i.Render(w, r, "test/component", nil)
+	}()
+
+	<-started
+
+	shared := make(chan struct{})
+
+	go func() {
+		i.Share("title", "Inertia.js Go")
+		i.ShareFunc("asset", func(string) string { return "" })
+		i.EnableSsr(ts.URL)
+		close(shared)
+	}()
+
+	select {
+	case <-shared:
+	case <-time.After(time.Second):
+		t.Error("expected writers not to wait for the SSR call")
+	}
+
+	close(release)
+
+	err := <-done
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if i.SharedProps()["title"] != "Inertia.js Go" {
+		t.Errorf("expected: Inertia.js Go, got: %v", i.SharedProps()["title"])
+	}
+}
+
+func BenchmarkShareDuringSsrRender(b *testing.B) {
+	var once sync.Once
+
+	ready := make(chan struct{})
+
+	ts := newSsrTestServer(b, func() {
+		once.Do(func() { close(ready) })
+		time.Sleep(5 * time.Millisecond)
+	})
+
+	i := newSsrTestInertia(ts.URL, ssrTestTemplate)
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+
+	for range 8 {
+		go func() {
+			defer func() { done <- struct{}{} }()
+
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+
+				r := httptest.NewRequest(http.MethodGet, "/", nil)
+				w := httptest.NewRecorder()
+
+				i.Render(w, r, "test/component", nil)
+			}
+		}()
+	}
+
+	<-ready
+
+	for n := 0; b.Loop(); n++ {
+		i.Share("key", n)
+	}
+
+	close(stop)
+
+	for range 8 {
+		<-done
+	}
+}
+
+func BenchmarkRenderParallel(b *testing.B) {
+	i := New("http://inertia-go.test", "", "")
+	i.Share("title", "Inertia.js Go")
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set(HeaderInertia, "true")
+			w := httptest.NewRecorder()
+
+			i.Render(w, r, "test/component", nil)
+		}
+	})
+}
+
+func BenchmarkRenderTemplateParallel(b *testing.B) {
+	templateFS := fstest.MapFS{
+		"app.gohtml": {Data: []byte(`<div id="app" data-page="{{ marshal .page }}"></div>`)},
+	}
+
+	i := New("http://inertia-go.test", "app.gohtml", "", templateFS)
+	i.Share("title", "Inertia.js Go")
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			w := httptest.NewRecorder()
+
+			err := i.Render(w, r, "test/component", nil)
+			if err != nil {
+				b.Error(err)
+			}
+		}
+	})
+}
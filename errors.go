@@ -6,6 +6,50 @@ var (
 	// ErrBadSsrStatusCode error.
 	ErrBadSsrStatusCode = errors.New("inertia: bad ssr status code >= 400")
 
+	// ErrSsrCircuitOpen error.
+	ErrSsrCircuitOpen = errors.New("inertia: ssr circuit breaker is open")
+
 	// ErrInvalidContextValue error.
+	//
+	// Deprecated: context values are read through the Response builder,
+	// which never returns this error.
 	ErrInvalidContextValue = errors.New("inertia: could not convert context value to expected type")
+
+	// ErrSessionNotStarted error.
+	ErrSessionNotStarted = errors.New("inertia: session not started, check the session store and middleware")
+
+	// ErrInvalidSession error.
+	ErrInvalidSession = errors.New("inertia: could not decode session data")
+
+	// ErrInvalidSessionKey error.
+	ErrInvalidSessionKey = errors.New("inertia: session key must be at least 32 bytes")
+
+	// ErrSessionTooLarge error.
+	ErrSessionTooLarge = errors.New("inertia: session data exceeds the cookie size limit")
+
+	// ErrViteChunkNotFound error.
+	ErrViteChunkNotFound = errors.New("inertia: vite chunk not found in manifest")
+
+	// ErrViteNotEnabled error.
+	ErrViteNotEnabled = errors.New("inertia: vite is not enabled, call EnableVite first")
+
+	// ErrInvalidStructProps error.
+	ErrInvalidStructProps = errors.New("inertia: invalid struct props")
 )
+
+// PropError type.
+type PropError struct {
+	Component string
+	Key       string
+	Err       error
+}
+
+// Error function.
+func (e *PropError) Error() string {
+	return "inertia: could not resolve prop " + e.Key + " of component " + e.Component + ": " + e.Err.Error()
+}
+
+// Unwrap function.
+func (e *PropError) Unwrap() error {
+	return e.Err
+}
@@ -24,4 +24,7 @@ const (
 
 	// HeaderReset header.
 	HeaderReset = "X-Inertia-Reset"
+
+	// HeaderErrorBag header.
+	HeaderErrorBag = "X-Inertia-Error-Bag"
 )
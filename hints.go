@@ -0,0 +1,103 @@
+package inertia
+
+import (
+	"net/http"
+	"path"
+	"strings"
+)
+
+// EnableEarlyHints function.
+func (i *Inertia) EnableEarlyHints(assets ...string) {
+	links := make([]string, 0, len(assets))
+
+	for _, asset := range assets {
+		links = append(links, preloadLink(asset))
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	c := i.snapshot()
+	c.earlyHints = func(*Page) ([]string, error) {
+		return links, nil
+	}
+
+	i.config.Store(c)
+}
+
+// EnableEarlyHintsWithVite function.
+func (i *Inertia) EnableEarlyHintsWithVite(entries ...string) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.vite == nil {
+		return ErrViteNotEnabled
+	}
+
+	v := i.vite
+
+	c := i.snapshot()
+	c.earlyHints = func(page *Page) ([]string, error) {
+		return v.preloadLinks(page.Component, entries...)
+	}
+
+	i.config.Store(c)
+
+	return nil
+}
+
+// DisableEarlyHints function.
+func (i *Inertia) DisableEarlyHints() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	c := i.snapshot()
+	c.earlyHints = nil
+
+	i.config.Store(c)
+}
+
+func (c *config) sendEarlyHints(w http.ResponseWriter, page *Page) error {
+	if c.earlyHints == nil {
+		return nil
+	}
+
+	links, err := c.earlyHints(page)
+	if err != nil {
+		return err
+	}
+
+	if len(links) == 0 {
+		return nil
+	}
+
+	for _, link := range links {
+		w.Header().Add("Link", link)
+	}
+
+	w.WriteHeader(http.StatusEarlyHints)
+
+	return nil
+}
+
+func preloadLink(url string) string {
+	link := "<" + url + ">; rel=preload"
+
+	name := url
+	if index := strings.IndexAny(name, "?#"); index >= 0 {
+		name = name[:index]
+	}
+
+	switch strings.ToLower(path.Ext(name)) {
+	case ".css":
+		return link + "; as=style"
+	case ".js", ".mjs":
+		return link + "; as=script; crossorigin"
+	case ".woff", ".woff2", ".ttf", ".otf":
+		return link + "; as=font; crossorigin"
+	case ".png", ".jpg", ".jpeg", ".gif", ".svg", ".webp", ".avif":
+		return link + "; as=image"
+	}
+
+	return link + "; as=fetch; crossorigin"
+}
@@ -0,0 +1,141 @@
+package inertia
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"net/textproto"
+	"path/filepath"
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+func earlyHintsLinks(t *testing.T, i *Inertia) []string {
+	t.Helper()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := i.Render(w, r, "Users/Index", nil)
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+	defer ts.Close()
+
+	var links []string
+
+	trace := &httptrace.ClientTrace{
+		Got1xxResponse: func(code int, header textproto.MIMEHeader) error {
+			if code == http.StatusEarlyHints {
+				links = append(links, header.Values("Link")...)
+			}
+
+			return nil
+		},
+	}
+
+	req, err := http.NewRequestWithContext(httptrace.WithClientTrace(t.Context(), trace), http.MethodGet, ts.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := ts.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status code: %d, got: %d", http.StatusOK, resp.StatusCode)
+	}
+
+	return links
+}
+
+func TestEarlyHints(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"app.gohtml": {Data: []byte(`<div id="app"></div>`)},
+	}
+
+	i := New("http://inertia-go.test", "app.gohtml", "", templateFS)
+
+	if links := earlyHintsLinks(t, i); len(links) != 0 {
+		t.Errorf("expected no early hints, got: %v", links)
+	}
+
+	i.EnableEarlyHints("/build/app.css", "/build/app.js")
+
+	expected := []string{
+		"</build/app.css>; rel=preload; as=style",
+		"</build/app.js>; rel=preload; as=script; crossorigin",
+	}
+
+	if links := earlyHintsLinks(t, i); !slices.Equal(links, expected) {
+		t.Errorf("expected: %v, got: %v", expected, links)
+	}
+
+	i.DisableEarlyHints()
+
+	if links := earlyHintsLinks(t, i); len(links) != 0 {
+		t.Errorf("expected no early hints, got: %v", links)
+	}
+}
+
+func TestEarlyHintsWithVite(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"app.gohtml":                       {Data: []byte(`<div id="app"></div>`)},
+		"public/build/.vite/manifest.json": {Data: []byte(testViteManifest)},
+	}
+
+	i := New("http://inertia-go.test", "app.gohtml", "", templateFS)
+
+	err := i.EnableEarlyHintsWithVite("resources/js/app.js")
+	if err != ErrViteNotEnabled {
+		t.Errorf("expected: %v, got: %v", ErrViteNotEnabled, err)
+	}
+
+	i.EnableVite(ViteConfig{
+		HotFile: filepath.Join(t.TempDir(), "hot"),
+	})
+
+	err = i.EnableEarlyHintsWithVite("resources/js/app.js")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"</build/assets/app-7d8b5a3f.css>; rel=preload; as=style",
+		"</build/assets/vendor-1a2b3c4d.css>; rel=preload; as=style",
+		"</build/assets/Index-3c4d5e6f.css>; rel=preload; as=style",
+		"</build/assets/app-4ed993c7.js>; rel=preload; as=script; crossorigin",
+		"</build/assets/vendor-9a8b7c6d.js>; rel=preload; as=script; crossorigin",
+		"</build/assets/Index-5e6f7a8b.js>; rel=preload; as=script; crossorigin",
+	}
+
+	if links := earlyHintsLinks(t, i); !slices.Equal(links, expected) {
+		t.Errorf("expected: %v, got: %v", expected, links)
+	}
+}
+
+func TestEarlyHintsWithInertiaRequest(t *testing.T) {
+	i := New("http://inertia-go.test", "", "")
+	i.EnableEarlyHints("/build/app.js")
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(HeaderInertia, "true")
+	w := httptest.NewRecorder()
+
+	err := i.Render(w, r, "Users/Index", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status code: %d, got: %d", http.StatusOK, w.Code)
+	}
+
+	if links := w.Header().Values("Link"); len(links) != 0 {
+		t.Errorf("expected no link headers, got: %v", links)
+	}
+}
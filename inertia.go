@@ -3,67 +3,101 @@ package inertia
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"html/template"
 	"io/fs"
 	"maps"
 	"net/http"
+	"path"
+	"reflect"
 	"slices"
 	"sync"
+	"sync/atomic"
 )
 
 // Inertia type.
 type Inertia struct {
-	mu             sync.RWMutex
-	url            string
-	rootTemplate   string
-	version        string
-	sharedProps    map[string]any
-	sharedFuncMap  template.FuncMap
-	sharedViewData map[string]any
-	parsedTemplate *template.Template
-	templateFS     fs.FS
-	ssrURL         string
-	ssrClient      *http.Client
+	mu         sync.RWMutex
+	config     atomic.Pointer[config]
+	vite       *Vite
+	components map[string]reflect.Type
 }
 
 // New function.
 func New(url, rootTemplate, version string, templateFS ...fs.FS) *Inertia {
-	i := &Inertia{
+	c := &config{
 		url:            url,
 		rootTemplate:   rootTemplate,
 		version:        version,
 		sharedProps:    make(map[string]any),
 		sharedFuncMap:  template.FuncMap{"marshal": marshal, "raw": raw},
 		sharedViewData: make(map[string]any),
+		parsedTemplate: &parsedTemplate{},
 	}
 
 	if len(templateFS) > 0 && templateFS[0] != nil {
-		i.templateFS = templateFS[0]
+		c.templateFS = templateFS[0]
+	}
+
+	i := &Inertia{
+		components: make(map[string]reflect.Type),
 	}
 
+	i.config.Store(c)
+
 	return i
 }
 
 // IsSsrEnabled function.
 func (i *Inertia) IsSsrEnabled() bool {
-	i.mu.RLock()
-	defer i.mu.RUnlock()
-
-	return i.isSsrEnabled()
+	return i.config.Load().isSsrEnabled()
 }
 
 // EnableSsr function.
 func (i *Inertia) EnableSsr(ssrURL string, client ...*http.Client) {
+	i.EnableSsrWithRenderer(NewHTTPSsrRenderer(RoundRobin, []string{ssrURL}, client...))
+}
+
+// EnableSsrPool function.
+func (i *Inertia) EnableSsrPool(balancer SsrBalancer, ssrURLs []string, client ...*http.Client) {
+	i.EnableSsrWithRenderer(NewHTTPSsrRenderer(balancer, ssrURLs, client...))
+}
+
+// EnableSsrWithRenderer function.
+func (i *Inertia) EnableSsrWithRenderer(renderer SsrRenderer) {
 	i.mu.Lock()
 	defer i.mu.Unlock()
 
-	i.ssrURL = ssrURL
+	c := i.snapshot()
 
-	if len(client) > 0 && client[0] != nil {
-		i.ssrClient = client[0]
-	} else {
-		i.ssrClient = &http.Client{}
+	if r, ok := renderer.(*HTTPSsrRenderer); ok {
+		if r == nil {
+			renderer = nil
+		} else {
+			r.cooldown.Store(int64(c.ssrOptions.BackendCooldown))
+		}
 	}
+
+	c.ssrRenderer = renderer
+	c.ssrBreaker = newSsrBreaker(c.ssrOptions)
+
+	i.config.Store(c)
+}
+
+// SsrRenderer function.
+func (i *Inertia) SsrRenderer() SsrRenderer {
+	return i.config.Load().ssrRenderer
+}
+
+// SsrBackends function.
+func (i *Inertia) SsrBackends() []SsrBackend {
+	r, ok := i.config.Load().ssrRenderer.(*HTTPSsrRenderer)
+	if !ok {
+		return nil
+	}
+
+	return r.Backends()
 }
 
 // EnableSsrWithDefault function.
@@ -76,8 +110,158 @@ func (i *Inertia) DisableSsr() {
 	i.mu.Lock()
 	defer i.mu.Unlock()
 
-	i.ssrURL = ""
-	i.ssrClient = nil
+	c := i.snapshot()
+	c.ssrRenderer = nil
+
+	i.config.Store(c)
+}
+
+// SetSsrOptions function.
+func (i *Inertia) SetSsrOptions(options SsrOptions) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	c := i.snapshot()
+	c.ssrOptions = options
+	c.ssrBreaker = newSsrBreaker(options)
+
+	if r, ok := c.ssrRenderer.(*HTTPSsrRenderer); ok {
+		r.cooldown.Store(int64(options.BackendCooldown))
+	}
+
+	i.config.Store(c)
+}
+
+// SsrBreakerState function.
+func (i *Inertia) SsrBreakerState() BreakerState {
+	return i.config.Load().ssrBreaker.state()
+}
+
+// EnableSsrCache function.
+func (i *Inertia) EnableSsrCache(cache SsrCache) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	c := i.snapshot()
+	c.ssrCache = nil
+
+	if cache != nil {
+		c.ssrCache = &ssrCache{cache: cache}
+	}
+
+	i.config.Store(c)
+}
+
+// DisableSsrCache function.
+func (i *Inertia) DisableSsrCache() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	c := i.snapshot()
+	c.ssrCache = nil
+
+	i.config.Store(c)
+}
+
+// ExcludeSsrComponents function.
+func (i *Inertia) ExcludeSsrComponents(patterns ...string) error {
+	for _, pattern := range patterns {
+		_, err := path.Match(pattern, "")
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, pattern)
+		}
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	c := i.snapshot()
+	c.ssrExcludes = append(slices.Clip(c.ssrExcludes), patterns...)
+
+	i.config.Store(c)
+
+	return nil
+}
+
+// EnableSsrFallback function.
+func (i *Inertia) EnableSsrFallback(handler ...SsrErrorHandler) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	c := i.snapshot()
+	c.ssrFallback = logSsrError
+
+	if len(handler) > 0 && handler[0] != nil {
+		c.ssrFallback = handler[0]
+	}
+
+	i.config.Store(c)
+}
+
+// DisableSsrFallback function.
+func (i *Inertia) DisableSsrFallback() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	c := i.snapshot()
+	c.ssrFallback = nil
+
+	i.config.Store(c)
+}
+
+// SetVersionFunc function.
+func (i *Inertia) SetVersionFunc(versionFunc VersionFunc) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	c := i.snapshot()
+	c.versionFunc = versionFunc
+
+	i.config.Store(c)
+}
+
+// SetSessionStore function.
+func (i *Inertia) SetSessionStore(store SessionStore, handler ...SessionErrorHandler) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	c := i.snapshot()
+	c.sessionStore = store
+	c.sessionError = logSessionError
+
+	if len(handler) > 0 && handler[0] != nil {
+		c.sessionError = handler[0]
+	}
+
+	i.config.Store(c)
+}
+
+// EnableConcurrentProps function.
+func (i *Inertia) EnableConcurrentProps(limit ...int) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	c := i.snapshot()
+	c.concurrent = true
+	c.concurrency = 0
+
+	if len(limit) > 0 {
+		c.concurrency = limit[0]
+	}
+
+	i.config.Store(c)
+}
+
+// DisableConcurrentProps function.
+func (i *Inertia) DisableConcurrentProps() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	c := i.snapshot()
+	c.concurrent = false
+	c.concurrency = 0
+
+	i.config.Store(c)
 }
 
 // ShareFunc function.
@@ -85,8 +269,11 @@ func (i *Inertia) ShareFunc(key string, value any) {
 	i.mu.Lock()
 	defer i.mu.Unlock()
 
-	i.sharedFuncMap[key] = value
-	i.parsedTemplate = nil
+	c := i.snapshot()
+	c.sharedFuncMap = cloneSet(c.sharedFuncMap, key, value)
+	c.parsedTemplate = &parsedTemplate{}
+
+	i.config.Store(c)
 }
 
 // ShareViewData function.
@@ -94,12 +281,15 @@ func (i *Inertia) ShareViewData(key string, value any) {
 	i.mu.Lock()
 	defer i.mu.Unlock()
 
-	i.sharedViewData[key] = value
+	c := i.snapshot()
+	c.sharedViewData = cloneSet(c.sharedViewData, key, value)
+
+	i.config.Store(c)
 }
 
 // WithViewData function.
 func (i *Inertia) WithViewData(ctx context.Context, key string, value any) context.Context {
-	return contextSet(ctx, contextKeyViewData, key, value)
+	return FromContext(ctx).WithViewData(key, value).NewContext(ctx)
 }
 
 // Share function.
@@ -107,125 +297,211 @@ func (i *Inertia) Share(key string, value any) {
 	i.mu.Lock()
 	defer i.mu.Unlock()
 
-	i.sharedProps[key] = value
+	c := i.snapshot()
+	c.sharedProps = cloneSet(c.sharedProps, key, value)
+
+	i.config.Store(c)
+}
+
+// SharedProps function.
+func (i *Inertia) SharedProps() map[string]any {
+	return maps.Clone(i.config.Load().sharedProps)
 }
 
 // WithProp function.
 func (i *Inertia) WithProp(ctx context.Context, key string, value any) context.Context {
-	return contextSet(ctx, contextKeyProps, key, value)
+	return FromContext(ctx).WithProp(key, value).NewContext(ctx)
 }
 
 // WithOptionalProp function.
 func (i *Inertia) WithOptionalProp(ctx context.Context, key string, value func() any) context.Context {
-	return contextSet(ctx, contextKeyOptionalProps, key, value)
+	return i.WithOptionalPropContext(ctx, key, lazyFunc(value))
+}
+
+// WithOptionalPropContext function.
+func (i *Inertia) WithOptionalPropContext(ctx context.Context, key string, value func(context.Context) (any, error)) context.Context {
+	return FromContext(ctx).WithOptionalPropContext(key, value).NewContext(ctx)
 }
 
 // WithAlwaysProp function.
 func (i *Inertia) WithAlwaysProp(ctx context.Context, key string, value func() any) context.Context {
-	return contextSet(ctx, contextKeyAlwaysProps, key, value)
+	return i.WithAlwaysPropContext(ctx, key, lazyFunc(value))
+}
+
+// WithAlwaysPropContext function.
+func (i *Inertia) WithAlwaysPropContext(ctx context.Context, key string, value func(context.Context) (any, error)) context.Context {
+	return FromContext(ctx).WithAlwaysPropContext(key, value).NewContext(ctx)
 }
 
 // WithDeferredProp function.
 func (i *Inertia) WithDeferredProp(ctx context.Context, key string, value func() any, group ...string) context.Context {
-	g := "default"
-	if len(group) > 0 && group[0] != "" {
-		g = group[0]
-	}
+	return i.WithDeferredPropContext(ctx, key, lazyFunc(value), group...)
+}
 
-	return contextSet(ctx, contextKeyDeferredProps, key, contextDeferredProp{Group: g, Value: value})
+// WithDeferredPropContext function.
+func (i *Inertia) WithDeferredPropContext(ctx context.Context, key string, value func(context.Context) (any, error), group ...string) context.Context {
+	return FromContext(ctx).WithDeferredPropContext(key, value, group...).NewContext(ctx)
 }
 
 // WithMergeProp function.
 func (i *Inertia) WithMergeProp(ctx context.Context, key string, value func() any, matchOn ...string) context.Context {
-	return contextSet(ctx, contextKeyMergeProps, key, contextMergeableProp{MatchOn: matchOn, Value: value})
+	return i.WithMergePropContext(ctx, key, lazyFunc(value), matchOn...)
+}
+
+// WithMergePropContext function.
+func (i *Inertia) WithMergePropContext(ctx context.Context, key string, value func(context.Context) (any, error), matchOn ...string) context.Context {
+	return FromContext(ctx).WithMergePropContext(key, value, matchOn...).NewContext(ctx)
 }
 
 // WithDeepMergeProp function.
 func (i *Inertia) WithDeepMergeProp(ctx context.Context, key string, value func() any, matchOn ...string) context.Context {
-	return contextSet(ctx, contextKeyDeepMergeProps, key, contextMergeableProp{MatchOn: matchOn, Value: value})
+	return i.WithDeepMergePropContext(ctx, key, lazyFunc(value), matchOn...)
+}
+
+// WithDeepMergePropContext function.
+func (i *Inertia) WithDeepMergePropContext(ctx context.Context, key string, value func(context.Context) (any, error), matchOn ...string) context.Context {
+	return FromContext(ctx).WithDeepMergePropContext(key, value, matchOn...).NewContext(ctx)
 }
 
 // WithPrependProp function.
 func (i *Inertia) WithPrependProp(ctx context.Context, key string, value func() any, matchOn ...string) context.Context {
-	return contextSet(ctx, contextKeyPrependProps, key, contextMergeableProp{MatchOn: matchOn, Value: value})
+	return i.WithPrependPropContext(ctx, key, lazyFunc(value), matchOn...)
+}
+
+// WithPrependPropContext function.
+func (i *Inertia) WithPrependPropContext(ctx context.Context, key string, value func(context.Context) (any, error), matchOn ...string) context.Context {
+	return FromContext(ctx).WithPrependPropContext(key, value, matchOn...).NewContext(ctx)
 }
 
 // WithScrollProp function.
 func (i *Inertia) WithScrollProp(ctx context.Context, key string, prop ScrollPageProp) context.Context {
-	return contextSet(ctx, contextKeyScrollProp, key, prop)
+	return FromContext(ctx).WithScrollProp(key, prop).NewContext(ctx)
 }
 
 // WithOnceProp function.
 func (i *Inertia) WithOnceProp(ctx context.Context, key string, value func() any) context.Context {
-	return contextSet(ctx, contextKeyOnceProps, key, value)
+	return i.WithOncePropContext(ctx, key, lazyFunc(value))
+}
+
+// WithOncePropContext function.
+func (i *Inertia) WithOncePropContext(ctx context.Context, key string, value func(context.Context) (any, error)) context.Context {
+	return FromContext(ctx).WithOncePropContext(key, value).NewContext(ctx)
 }
 
 // WithOnce function.
 func (i *Inertia) WithOnce(ctx context.Context, key string, prop ...OncePageProp) context.Context {
-	p := OncePageProp{}
-	if len(prop) > 0 {
-		p = prop[0]
-	}
-
-	p.Prop = key
-
-	return contextSet(ctx, contextKeyOnce, key, p)
+	return FromContext(ctx).WithOnce(key, prop...).NewContext(ctx)
 }
 
 // WithErrorProp function.
 func (i *Inertia) WithErrorProp(ctx context.Context, key string, value any) context.Context {
-	return contextSet(ctx, contextKeyErrors, key, value)
+	return FromContext(ctx).WithErrorProp(key, value).NewContext(ctx)
+}
+
+// WithErrorBagProp function.
+func (i *Inertia) WithErrorBagProp(ctx context.Context, bag, key string, value any) context.Context {
+	return FromContext(ctx).WithErrorBagProp(bag, key, value).NewContext(ctx)
 }
 
 // WithFlash function.
 func (i *Inertia) WithFlash(ctx context.Context, data map[string]any) context.Context {
-	return context.WithValue(ctx, contextKeyFlash, data)
+	return FromContext(ctx).WithFlash(data).NewContext(ctx)
+}
+
+// Flash function.
+func (i *Inertia) Flash(ctx context.Context, data map[string]any) error {
+	sess := sessionFromContext(ctx)
+	if sess == nil {
+		return ErrSessionNotStarted
+	}
+
+	sess.flash(data)
+
+	return nil
+}
+
+// FlashErrors function.
+func (i *Inertia) FlashErrors(ctx context.Context, errors map[string]any) error {
+	sess := sessionFromContext(ctx)
+	if sess == nil {
+		return ErrSessionNotStarted
+	}
+
+	sess.flashErrors(errors)
+
+	return nil
 }
 
 // WithClearHistory function.
 func (i *Inertia) WithClearHistory(ctx context.Context) context.Context {
-	return context.WithValue(ctx, contextKeyClearHistory, true)
+	return FromContext(ctx).WithClearHistory().NewContext(ctx)
 }
 
 // WithEncryptHistory function.
 func (i *Inertia) WithEncryptHistory(ctx context.Context) context.Context {
-	return context.WithValue(ctx, contextKeyEncryptHistory, true)
+	return FromContext(ctx).WithEncryptHistory().NewContext(ctx)
 }
 
 // WithPreserveFragment function.
 func (i *Inertia) WithPreserveFragment(ctx context.Context) context.Context {
-	return context.WithValue(ctx, contextKeyPreserveFragment, true)
+	return FromContext(ctx).WithPreserveFragment().NewContext(ctx)
+}
+
+// WithoutSsr function.
+func (i *Inertia) WithoutSsr(ctx context.Context) context.Context {
+	return FromContext(ctx).WithoutSsr().NewContext(ctx)
 }
 
 // Middleware function.
 func (i *Inertia) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c := i.config.Load()
+
+		var sess *session
+
+		if c.sessionStore != nil {
+			sess = newSession(w, r, c.sessionStore, c.sessionError)
+			r = r.WithContext(context.WithValue(r.Context(), contextKeySession, sess))
+		}
+
 		if r.Header.Get(HeaderInertia) == "" {
-			next.ServeHTTP(w, r)
+			if sess == nil {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			rw := newResponseWriter(w, r, sess)
+
+			next.ServeHTTP(rw, r)
+
+			rw.commit()
 
 			return
 		}
 
-		i.mu.RLock()
-		version := i.version
-		url := i.url
-		i.mu.RUnlock()
+		if r.Method == http.MethodGet && r.Header.Get(HeaderVersion) != c.resolveVersion(r) {
+			if sess != nil {
+				sess.reflash(w, r)
+			}
 
-		if r.Method == http.MethodGet && r.Header.Get(HeaderVersion) != version {
-			w.Header().Set(HeaderLocation, url+r.RequestURI)
+			w.Header().Set(HeaderLocation, c.url+r.RequestURI)
 			w.WriteHeader(http.StatusConflict)
 
 			return
 		}
 
-		next.ServeHTTP(w, r)
+		rw := newResponseWriter(w, r, sess)
+
+		next.ServeHTTP(rw, r)
+
+		rw.commit()
 	})
 }
 
 // Render function.
 func (i *Inertia) Render(w http.ResponseWriter, r *http.Request, component string, props map[string]any) error {
-	i.mu.RLock()
-	defer i.mu.RUnlock()
+	c := i.config.Load()
 
 	rt := newRuntime(r, component, props)
 
@@ -233,21 +509,21 @@ func (i *Inertia) Render(w http.ResponseWriter, r *http.Request, component strin
 		Component: component,
 		Props:     make(map[string]any),
 		URL:       r.RequestURI,
-		Version:   i.version,
+		Version:   c.resolveVersion(r),
 	}
 
 	for _, create := range []func(*http.Request, *runtime, *Page) error{
-		i.createBaseProps,
-		i.createOptionalProps,
-		i.createAlwaysProps,
-		i.createDeferredProps,
-		i.createMergeProps,
-		i.createDeepMergeProps,
-		i.createPrependProps,
-		i.createScrollProps,
-		i.createOnceProps,
-		i.createOnceModifiers,
-		i.createErrorProps,
+		c.createBaseProps,
+		c.createOptionalProps,
+		c.createAlwaysProps,
+		c.createDeferredProps,
+		c.createMergeProps,
+		c.createDeepMergeProps,
+		c.createPrependProps,
+		c.createScrollProps,
+		c.createOnceProps,
+		c.createOnceModifiers,
+		c.createErrorProps,
 	} {
 		err := create(r, rt, page)
 		if err != nil {
@@ -255,36 +531,58 @@ func (i *Inertia) Render(w http.ResponseWriter, r *http.Request, component strin
 		}
 	}
 
-	if len(i.sharedProps) > 0 {
-		page.SharedProps = slices.Sorted(maps.Keys(i.sharedProps))
+	err := c.resolveProps(r.Context(), page)
+	if err != nil {
+		return err
 	}
 
-	flash, ok := r.Context().Value(contextKeyFlash).(map[string]any)
-	if ok {
-		page.Flash = flash
+	err = filterProps(r.Context(), rt, page)
+	if err != nil {
+		return err
 	}
 
-	clearHistory, ok := r.Context().Value(contextKeyClearHistory).(bool)
-	if ok {
-		page.ClearHistory = clearHistory
+	err = resolveNestedProps(r.Context(), page)
+	if err != nil {
+		return err
 	}
 
-	encryptHistory, ok := r.Context().Value(contextKeyEncryptHistory).(bool)
-	if ok {
-		page.EncryptHistory = encryptHistory
+	sortPage(page)
+
+	if len(c.sharedProps) > 0 {
+		page.SharedProps = slices.Sorted(maps.Keys(c.sharedProps))
+	}
+
+	if sess := sessionFromContext(r.Context()); sess != nil {
+		data := sess.consume()
+
+		if len(data.Flash) > 0 {
+			page.Flash = maps.Clone(data.Flash)
+		}
 	}
 
-	preserveFragment, ok := r.Context().Value(contextKeyPreserveFragment).(bool)
-	if ok {
-		page.PreserveFragment = preserveFragment
+	if len(rt.response.flash) > 0 {
+		if page.Flash == nil {
+			page.Flash = make(map[string]any, len(rt.response.flash))
+		}
+
+		maps.Copy(page.Flash, rt.response.flash)
 	}
 
+	page.ClearHistory = rt.response.clearHistory
+	page.EncryptHistory = rt.response.encryptHistory
+	page.PreserveFragment = rt.response.preserveFragment
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+
 	if r.Header.Get(HeaderInertia) != "" {
-		js, err := json.Marshal(page)
+		err = json.NewEncoder(buf).Encode(page)
 		if err != nil {
 			return err
 		}
 
+		buf.Truncate(buf.Len() - 1)
+
 		if w.Header().Get("Vary") == "" {
 			w.Header().Set("Vary", HeaderInertia)
 		} else {
@@ -292,39 +590,47 @@ func (i *Inertia) Render(w http.ResponseWriter, r *http.Request, component strin
 		}
 
 		w.Header().Set(HeaderInertia, "true")
-		w.Header().Set("Content-Type", "application/json")
-
-		_, err = w.Write(js)
 
-		return err
+		return writeBuffer(w, "application/json", buf)
 	}
 
-	rootTemplate, err := i.createRootTemplate()
+	err = c.sendEarlyHints(w, page)
 	if err != nil {
 		return err
 	}
 
-	w.Header().Set("Content-Type", "text/html")
-
-	viewData, err := i.createViewData(r)
+	rootTemplate, err := c.createRootTemplate()
 	if err != nil {
 		return err
 	}
 
+	viewData := c.createViewData(rt)
 	viewData["page"] = page
 
-	if i.isSsrEnabled() {
-		ssr, err := i.ssr(r.Context(), page)
-		if err != nil {
+	if c.isSsrEnabled() && !rt.response.withoutSsr && !c.isSsrExcluded(page.Component) {
+		ssr, err := c.ssr(r.Context(), page)
+
+		switch {
+		case err == nil:
+			viewData["ssr"] = ssr
+		case errors.Is(err, ErrSsrCircuitOpen):
+			viewData["ssr"] = nil
+		case c.ssrFallback != nil:
+			c.ssrFallback(r, page, err)
+			viewData["ssr"] = nil
+		default:
 			return err
 		}
-
-		viewData["ssr"] = ssr
 	} else {
 		viewData["ssr"] = nil
 	}
 
-	return rootTemplate.Execute(w, viewData)
+	err = rootTemplate.Execute(buf, viewData)
+	if err != nil {
+		return err
+	}
+
+	return writeBuffer(w, "text/html; charset=utf-8", buf)
 }
 
 // Location function.
@@ -336,3 +642,21 @@ func (i *Inertia) Location(w http.ResponseWriter, r *http.Request, url string) {
 		http.Redirect(w, r, url, http.StatusFound)
 	}
 }
+
+// Back function.
+func (i *Inertia) Back(w http.ResponseWriter, r *http.Request, fallback string) {
+	url := i.config.Load().url
+
+	target := fallback
+
+	if referer := r.Referer(); referer != "" && isSameOrigin(url, referer) {
+		target = referer
+	}
+
+	status := http.StatusFound
+	if r.Header.Get(HeaderInertia) != "" && r.Method != http.MethodGet {
+		status = http.StatusSeeOther
+	}
+
+	http.Redirect(w, r, target, status)
+}
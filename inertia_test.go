@@ -1,19 +1,27 @@
 package inertia
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"testing/fstest"
+	"time"
 )
 
 func TestNew(t *testing.T) {
 	i := New("http://inertia-go.test", "app.gohtml", "1")
 
-	if i.templateFS != nil {
+	if i.config.Load().templateFS != nil {
 		t.Error("expected: nil, got: fs.FS")
 	}
 }
@@ -23,7 +31,7 @@ func TestNewWithFS(t *testing.T) {
 
 	i := New("http://inertia-go.test", "app.gohtml", "1", templateFS)
 
-	if i.templateFS == nil {
+	if i.config.Load().templateFS == nil {
 		t.Error("expected: fs.FS, got: nil")
 	}
 }
@@ -32,11 +40,11 @@ func TestEnableSsr(t *testing.T) {
 	i := New("", "", "")
 	i.EnableSsr("ssr.test")
 
-	if i.ssrURL != "ssr.test" {
-		t.Errorf("expected: ssr.test, got: %v", i.ssrURL)
+	if i.config.Load().ssrRenderer.(*HTTPSsrRenderer).backends[0].url != "ssr.test" {
+		t.Errorf("expected: ssr.test, got: %v", i.config.Load().ssrRenderer.(*HTTPSsrRenderer).backends[0].url)
 	}
 
-	if i.ssrClient == nil {
+	if i.config.Load().ssrRenderer.(*HTTPSsrRenderer).backends[0].client == nil {
 		t.Error("expected: *http.Client, got: nil")
 	}
 }
@@ -46,11 +54,11 @@ func TestEnableSsrWithClient(t *testing.T) {
 	client := &http.Client{}
 	i.EnableSsr("ssr.test", client)
 
-	if i.ssrURL != "ssr.test" {
-		t.Errorf("expected: ssr.test, got: %v", i.ssrURL)
+	if i.config.Load().ssrRenderer.(*HTTPSsrRenderer).backends[0].url != "ssr.test" {
+		t.Errorf("expected: ssr.test, got: %v", i.config.Load().ssrRenderer.(*HTTPSsrRenderer).backends[0].url)
 	}
 
-	if i.ssrClient != client {
+	if i.config.Load().ssrRenderer.(*HTTPSsrRenderer).backends[0].client != client {
 		t.Error("expected: custom *http.Client, got: different client")
 	}
 }
@@ -79,11 +87,11 @@ func TestEnableSsrWithDefault(t *testing.T) {
 	i := New("", "", "")
 	i.EnableSsrWithDefault()
 
-	if i.ssrURL != "http://127.0.0.1:13714/render" {
-		t.Errorf("expected: http://127.0.0.1:13714/render, got: %v", i.ssrURL)
+	if i.config.Load().ssrRenderer.(*HTTPSsrRenderer).backends[0].url != "http://127.0.0.1:13714/render" {
+		t.Errorf("expected: http://127.0.0.1:13714/render, got: %v", i.config.Load().ssrRenderer.(*HTTPSsrRenderer).backends[0].url)
 	}
 
-	if i.ssrClient == nil {
+	if i.config.Load().ssrRenderer.(*HTTPSsrRenderer).backends[0].client == nil {
 		t.Error("expected: *http.Client, got: nil")
 	}
 }
@@ -93,11 +101,11 @@ func TestEnableSsrWithDefaultWithClient(t *testing.T) {
 	client := &http.Client{}
 	i.EnableSsrWithDefault(client)
 
-	if i.ssrURL != "http://127.0.0.1:13714/render" {
-		t.Errorf("expected: http://127.0.0.1:13714/render, got: %v", i.ssrURL)
+	if i.config.Load().ssrRenderer.(*HTTPSsrRenderer).backends[0].url != "http://127.0.0.1:13714/render" {
+		t.Errorf("expected: http://127.0.0.1:13714/render, got: %v", i.config.Load().ssrRenderer.(*HTTPSsrRenderer).backends[0].url)
 	}
 
-	if i.ssrClient != client {
+	if i.config.Load().ssrRenderer.(*HTTPSsrRenderer).backends[0].client != client {
 		t.Error("expected: custom *http.Client, got: different client")
 	}
 }
@@ -125,8 +133,8 @@ func TestDisableSsr(t *testing.T) {
 		t.Error("expected: false, got: true")
 	}
 
-	if i.ssrClient != nil {
-		t.Error("expected: nil, got: *http.Client")
+	if i.config.Load().ssrRenderer != nil {
+		t.Error("expected: nil, got: SsrRenderer")
 	}
 }
 
@@ -157,7 +165,7 @@ func TestShareFunc(t *testing.T) {
 		return "/" + path, nil
 	})
 
-	_, ok := i.sharedFuncMap["asset"].(func(string) (string, error))
+	_, ok := i.config.Load().sharedFuncMap["asset"].(func(string) (string, error))
 	if !ok {
 		t.Error("expected: asset func, got: empty value")
 	}
@@ -211,7 +219,7 @@ func TestShareViewData(t *testing.T) {
 	i := New("", "", "")
 	i.ShareViewData("env", "production")
 
-	env, ok := i.sharedViewData["env"].(string)
+	env, ok := i.config.Load().sharedViewData["env"].(string)
 	if !ok {
 		t.Error("expected: env, got: empty value")
 	}
@@ -271,12 +279,7 @@ func TestWithViewData(t *testing.T) {
 	i := New("", "", "")
 	ctx = i.WithViewData(ctx, "meta", "test-meta")
 
-	contextViewData, ok := ctx.Value(contextKeyViewData).(map[string]any)
-	if !ok {
-		t.Error("expected: context view data, got: empty value")
-	}
-
-	meta, ok := contextViewData["meta"].(string)
+	meta, ok := FromContext(ctx).viewData["meta"].(string)
 	if !ok {
 		t.Error("expected: meta, got: empty value")
 	}
@@ -290,7 +293,7 @@ func TestShare(t *testing.T) {
 	i := New("", "", "")
 	i.Share("title", "Inertia.js Go")
 
-	title, ok := i.sharedProps["title"].(string)
+	title, ok := i.config.Load().sharedProps["title"].(string)
 	if !ok {
 		t.Error("expected: title, got: empty value")
 	}
@@ -350,12 +353,7 @@ func TestWithProp(t *testing.T) {
 	i := New("", "", "")
 	ctx = i.WithProp(ctx, "user", "test-user")
 
-	contextProps, ok := ctx.Value(contextKeyProps).(map[string]any)
-	if !ok {
-		t.Error("expected: context props, got: empty value")
-	}
-
-	user, ok := contextProps["user"].(string)
+	user, ok := FromContext(ctx).props["user"].(string)
 	if !ok {
 		t.Error("expected: user, got: empty value")
 	}
@@ -371,12 +369,7 @@ func TestWithErrorProp(t *testing.T) {
 	i := New("", "", "")
 	ctx = i.WithErrorProp(ctx, "email", "Invalid email")
 
-	contextErrors, ok := ctx.Value(contextKeyErrors).(map[string]any)
-	if !ok {
-		t.Error("expected: context errors, got: empty value")
-	}
-
-	message, ok := contextErrors["email"].(string)
+	message, ok := FromContext(ctx).errors["email"].(string)
 	if !ok {
 		t.Error("expected: email, got: empty value")
 	}
@@ -428,6 +421,50 @@ func TestMiddlewareWithNormalRequest(t *testing.T) {
 	}
 }
 
+type hijackRecorder struct {
+	*httptest.ResponseRecorder
+	hijacked bool
+}
+
+func (w *hijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.hijacked = true
+
+	return nil, nil, nil
+}
+
+func TestMiddlewareWithHijacker(t *testing.T) {
+	store, err := NewCookieSessionStore("inertia_session", testSessionKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, withSession := range []bool{false, true} {
+		i := New("http://inertia-go.test", "", "")
+
+		if withSession {
+			i.SetSessionStore(store)
+		}
+
+		m := i.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hijacker, ok := w.(http.Hijacker)
+			if !ok {
+				t.Fatal("expected the writer to implement http.Hijacker")
+			}
+
+			hijacker.Hijack()
+		}))
+
+		r := httptest.NewRequest(http.MethodGet, "/ws", nil)
+		w := &hijackRecorder{ResponseRecorder: httptest.NewRecorder()}
+
+		m.ServeHTTP(w, r)
+
+		if !w.hijacked {
+			t.Errorf("session %v expected the connection to be hijacked", withSession)
+		}
+	}
+}
+
 func TestMiddlewareWithInertiaRequest(t *testing.T) {
 	url := "http://inertia-go.test"
 
@@ -499,6 +536,49 @@ func TestMiddlewareWithInertiaRequest(t *testing.T) {
 	}
 }
 
+func TestMiddlewareWithInertiaRedirect(t *testing.T) {
+	i := New("http://inertia-go.test", "", "abc123")
+	ih := func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/users", http.StatusFound)
+	}
+
+	m := i.Middleware(http.HandlerFunc(ih))
+
+	for method, expected := range map[string]int{
+		http.MethodGet:    http.StatusFound,
+		http.MethodPost:   http.StatusFound,
+		http.MethodPut:    http.StatusSeeOther,
+		http.MethodPatch:  http.StatusSeeOther,
+		http.MethodDelete: http.StatusSeeOther,
+	} {
+		r := httptest.NewRequest(method, "/", nil)
+		r.Header.Set(HeaderInertia, "true")
+		r.Header.Set(HeaderVersion, "abc123")
+		w := httptest.NewRecorder()
+
+		m.ServeHTTP(w, r)
+
+		resp := w.Result()
+
+		if resp.StatusCode != expected {
+			t.Errorf("%s expected status code: %d, got: %d", method, expected, resp.StatusCode)
+		}
+
+		if resp.Header.Get("Location") != "/users" {
+			t.Errorf("%s expected location: /users, got: %s", method, resp.Header.Get("Location"))
+		}
+	}
+
+	r := httptest.NewRequest(http.MethodPut, "/", nil)
+	w := httptest.NewRecorder()
+
+	m.ServeHTTP(w, r)
+
+	if w.Result().StatusCode != http.StatusFound {
+		t.Errorf("expected status code: %d, got: %d", http.StatusFound, w.Result().StatusCode)
+	}
+}
+
 func TestRender(t *testing.T) {
 	url := "http://inertia-go.test"
 	i := New(url, "", "")
@@ -559,6 +639,72 @@ func TestRender(t *testing.T) {
 	if userID != 1 {
 		t.Errorf("expected: 1, got: %.2f", userID)
 	}
+
+	if resp.Header.Get("Content-Length") != strconv.Itoa(w.Body.Len()) {
+		t.Errorf("expected: %d, got: %s", w.Body.Len(), resp.Header.Get("Content-Length"))
+	}
+}
+
+func TestRenderWithRootTemplate(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"app.gohtml": {Data: []byte(`<div id="app" data-page="{{ marshal .page }}"></div>`)},
+	}
+
+	i := New("http://inertia-go.test", "app.gohtml", "", templateFS)
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+
+	err := i.Render(w, r, "test/component", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := w.Result()
+
+	if resp.Header.Get("Content-Type") != "text/html; charset=utf-8" {
+		t.Errorf("expected: text/html; charset=utf-8, got: %s", resp.Header.Get("Content-Type"))
+	}
+
+	if resp.Header.Get("Content-Length") != strconv.Itoa(w.Body.Len()) {
+		t.Errorf("expected: %d, got: %s", w.Body.Len(), resp.Header.Get("Content-Length"))
+	}
+
+	if !strings.HasPrefix(w.Body.String(), `<div id="app"`) {
+		t.Errorf("unexpected body: %s", w.Body.String())
+	}
+}
+
+func TestRenderWithRootTemplateError(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"app.gohtml": {Data: []byte(`<html><body>{{ fail }}</body></html>`)},
+	}
+
+	i := New("http://inertia-go.test", "app.gohtml", "", templateFS)
+	i.ShareFunc("fail", func() (string, error) {
+		return "", errors.New("template failed")
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+
+	err := i.Render(w, r, "test/component", nil)
+	if err == nil {
+		t.Fatal("expected: error, got: nil")
+	}
+
+	if w.Body.Len() != 0 {
+		t.Errorf("expected empty body, got: %s", w.Body.String())
+	}
+
+	if w.Header().Get("Content-Type") != "" {
+		t.Errorf("expected empty Content-Type, got: %s", w.Header().Get("Content-Type"))
+	}
+
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected status code: %d, got: %d", http.StatusInternalServerError, w.Code)
+	}
 }
 
 func TestRenderWithSharedProps(t *testing.T) {
@@ -1188,6 +1334,92 @@ func TestRenderWithErrorProps(t *testing.T) {
 	}
 }
 
+func TestRenderWithErrorBag(t *testing.T) {
+	i := New("http://inertia-go.test", "", "")
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(HeaderInertia, "true")
+	r.Header.Set(HeaderErrorBag, "createUser")
+	ctx := i.WithErrorProp(r.Context(), "email", "Invalid")
+	ctx = i.WithErrorBagProp(ctx, "createUser", "name", "Required")
+	ctx = i.WithErrorBagProp(ctx, "updateUser", "name", "Too long")
+	r = r.WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	err := i.Render(w, r, "test/component", nil)
+	if err != nil {
+		t.Error(err)
+	}
+
+	var page Page
+
+	err = json.NewDecoder(w.Result().Body).Decode(&page)
+	if err != nil {
+		t.Error(err)
+	}
+
+	errors, ok := page.Props["errors"].(map[string]any)
+	if !ok {
+		t.Fatal("expected: errors map in props, got: empty value")
+	}
+
+	if len(errors) != 1 {
+		t.Errorf("expected 1 error bag, got: %v", errors)
+	}
+
+	bag, ok := errors["createUser"].(map[string]any)
+	if !ok {
+		t.Fatal("expected: createUser bag in errors, got: empty value")
+	}
+
+	if bag["email"] != "Invalid" {
+		t.Errorf("expected: Invalid, got: %v", bag["email"])
+	}
+
+	if bag["name"] != "Required" {
+		t.Errorf("expected: Required, got: %v", bag["name"])
+	}
+}
+
+func TestRenderWithErrorBagWithoutHeader(t *testing.T) {
+	i := New("http://inertia-go.test", "", "")
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(HeaderInertia, "true")
+	ctx := i.WithErrorProp(r.Context(), "email", "Invalid")
+	ctx = i.WithErrorBagProp(ctx, "updateUser", "name", "Too long")
+	r = r.WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	err := i.Render(w, r, "test/component", nil)
+	if err != nil {
+		t.Error(err)
+	}
+
+	var page Page
+
+	err = json.NewDecoder(w.Result().Body).Decode(&page)
+	if err != nil {
+		t.Error(err)
+	}
+
+	errors, ok := page.Props["errors"].(map[string]any)
+	if !ok {
+		t.Fatal("expected: errors map in props, got: empty value")
+	}
+
+	if errors["email"] != "Invalid" {
+		t.Errorf("expected: Invalid, got: %v", errors["email"])
+	}
+
+	bag, ok := errors["updateUser"].(map[string]any)
+	if !ok {
+		t.Fatal("expected: updateUser bag in errors, got: empty value")
+	}
+
+	if bag["name"] != "Too long" {
+		t.Errorf("expected: Too long, got: %v", bag["name"])
+	}
+}
+
 func TestRenderWithFlash(t *testing.T) {
 	i := New("http://inertia-go.test", "", "")
 	r := httptest.NewRequest(http.MethodGet, "/", nil)
@@ -1324,6 +1556,377 @@ func TestRenderWithPartialExcept(t *testing.T) {
 	}
 }
 
+func TestRenderWithConcurrentProps(t *testing.T) {
+	i := New("http://inertia-go.test", "", "")
+	i.EnableConcurrentProps(3)
+
+	var started sync.WaitGroup
+
+	started.Add(3)
+
+	barrier := func(value string) func() any {
+		return func() any {
+			started.Done()
+
+			done := make(chan struct{})
+
+			go func() {
+				started.Wait()
+				close(done)
+			}()
+
+			select {
+			case <-done:
+				return value
+			case <-time.After(time.Second):
+				return "sequential"
+			}
+		}
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(HeaderInertia, "true")
+	ctx := i.WithAlwaysProp(r.Context(), "stats", barrier("stats"))
+	ctx = i.WithMergeProp(ctx, "feed", barrier("feed"))
+	ctx = i.WithMergeProp(ctx, "activity", barrier("activity"))
+	r = r.WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	err := i.Render(w, r, "test/component", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var page Page
+
+	err = json.NewDecoder(w.Result().Body).Decode(&page)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"stats", "feed", "activity"} {
+		if page.Props[key] != key {
+			t.Errorf("expected: %s, got: %v", key, page.Props[key])
+		}
+	}
+
+	if !slices.Equal(page.MergeProps, []string{"activity", "feed"}) {
+		t.Errorf("expected mergeProps [activity feed], got: %v", page.MergeProps)
+	}
+}
+
+func TestRenderWithConcurrentPropsCancelled(t *testing.T) {
+	i := New("http://inertia-go.test", "", "")
+	i.EnableConcurrentProps(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var calls int
+
+	r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/", nil)
+	r.Header.Set(HeaderInertia, "true")
+	ctx = i.WithAlwaysProp(r.Context(), "first", func() any {
+		calls++
+		cancel()
+
+		return "first"
+	})
+	ctx = i.WithAlwaysProp(ctx, "second", func() any {
+		calls++
+
+		return "second"
+	})
+	r = r.WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	err := i.Render(w, r, "test/component", nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected: %v, got: %v", context.Canceled, err)
+	}
+
+	if calls != 1 {
+		t.Errorf("expected 1 call, got: %d", calls)
+	}
+
+	if w.Body.Len() != 0 {
+		t.Errorf("expected empty body, got: %s", w.Body.String())
+	}
+}
+
+func TestRenderWithContextProp(t *testing.T) {
+	type ctxKey struct{}
+
+	i := New("http://inertia-go.test", "", "")
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(HeaderInertia, "true")
+	ctx := context.WithValue(r.Context(), ctxKey{}, "tenant")
+	ctx = i.WithAlwaysPropContext(ctx, "tenant", func(ctx context.Context) (any, error) {
+		return ctx.Value(ctxKey{}), nil
+	})
+	ctx = i.WithMergePropContext(ctx, "feed", func(ctx context.Context) (any, error) {
+		return []string{"a"}, nil
+	}, "id")
+	r = r.WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	err := i.Render(w, r, "test/component", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var page Page
+
+	err = json.NewDecoder(w.Result().Body).Decode(&page)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if page.Props["tenant"] != "tenant" {
+		t.Errorf("expected: tenant, got: %v", page.Props["tenant"])
+	}
+
+	if !slices.Equal(page.MatchPropsOn, []string{"feed.id"}) {
+		t.Errorf("expected matchPropsOn [feed.id], got: %v", page.MatchPropsOn)
+	}
+}
+
+func TestRenderWithContextPropError(t *testing.T) {
+	errQuery := errors.New("query failed")
+
+	for _, concurrent := range []bool{false, true} {
+		i := New("http://inertia-go.test", "", "")
+		if concurrent {
+			i.EnableConcurrentProps()
+		}
+
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set(HeaderInertia, "true")
+		ctx := i.WithAlwaysProp(r.Context(), "title", func() any { return "Test" })
+		ctx = i.WithAlwaysPropContext(ctx, "users", func(context.Context) (any, error) {
+			return nil, errQuery
+		})
+		r = r.WithContext(ctx)
+		w := httptest.NewRecorder()
+
+		err := i.Render(w, r, "Users/Index", nil)
+		if !errors.Is(err, errQuery) {
+			t.Errorf("expected: %v, got: %v", errQuery, err)
+		}
+
+		var propErr *PropError
+		if !errors.As(err, &propErr) {
+			t.Fatalf("expected: *PropError, got: %T", err)
+		}
+
+		if propErr.Key != "users" || propErr.Component != "Users/Index" {
+			t.Errorf("expected: users of Users/Index, got: %s of %s", propErr.Key, propErr.Component)
+		}
+
+		if w.Body.Len() != 0 {
+			t.Errorf("expected empty body, got: %s", w.Body.String())
+		}
+	}
+}
+
+func TestRenderWithNestedPartialOnly(t *testing.T) {
+	type team struct {
+		Name    string `json:"name"`
+		Members int    `json:"members"`
+	}
+
+	i := New("http://inertia-go.test", "", "")
+
+	var evaluated []string
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(HeaderInertia, "true")
+	r.Header.Set(HeaderPartialComponent, "test/component")
+	r.Header.Set(HeaderPartialOnly, "auth.user.permissions,auth.team.name,settings.theme,settings")
+	ctx := i.WithAlwaysProp(r.Context(), "auth", func() any {
+		return map[string]any{
+			"user": func() any {
+				evaluated = append(evaluated, "user")
+
+				return map[string]any{"name": "alice", "permissions": []string{"edit"}}
+			},
+			"notifications": func() any {
+				evaluated = append(evaluated, "notifications")
+
+				return 3
+			},
+			"team": &team{Name: "core", Members: 4},
+		}
+	})
+	r = r.WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	err := i.Render(w, r, "test/component", map[string]any{
+		"title":    "Test",
+		"settings": map[string]any{"theme": "dark", "locale": "en"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var page Page
+
+	err = json.NewDecoder(w.Result().Body).Decode(&page)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(evaluated, []string{"user"}) {
+		t.Errorf("expected only user to be evaluated, got: %v", evaluated)
+	}
+
+	if _, ok := page.Props["title"]; ok {
+		t.Error("expected title to be absent")
+	}
+
+	js, _ := json.Marshal(page.Props["auth"])
+	if string(js) != `{"team":{"name":"core"},"user":{"permissions":["edit"]}}` {
+		t.Errorf("expected pruned auth, got: %s", js)
+	}
+
+	js, _ = json.Marshal(page.Props["settings"])
+	if string(js) != `{"locale":"en","theme":"dark"}` {
+		t.Errorf("expected full settings, got: %s", js)
+	}
+}
+
+func TestRenderWithNestedPartialLargeNumber(t *testing.T) {
+	type user struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	}
+
+	i := New("http://inertia-go.test", "", "")
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(HeaderInertia, "true")
+	r.Header.Set(HeaderPartialComponent, "test/component")
+	r.Header.Set(HeaderPartialOnly, "auth.user.id")
+	w := httptest.NewRecorder()
+
+	err := i.Render(w, r, "test/component", map[string]any{
+		"auth": struct {
+			User user `json:"user"`
+		}{User: user{ID: 9007199254740993, Name: "alice"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := w.Body.String()
+	if !strings.Contains(body, `"auth":{"user":{"id":9007199254740993}}`) {
+		t.Errorf("expected the exact id, got: %s", body)
+	}
+}
+
+func TestRenderWithNestedPartialExcept(t *testing.T) {
+	i := New("http://inertia-go.test", "", "")
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(HeaderInertia, "true")
+	r.Header.Set(HeaderPartialComponent, "test/component")
+	r.Header.Set(HeaderPartialExcept, "auth.user.token")
+	w := httptest.NewRecorder()
+
+	err := i.Render(w, r, "test/component", map[string]any{
+		"title": "Test",
+		"auth": map[string]any{
+			"user": map[string]string{"name": "alice", "token": "secret"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var page Page
+
+	err = json.NewDecoder(w.Result().Body).Decode(&page)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if page.Props["title"] != "Test" {
+		t.Errorf("expected: Test, got: %v", page.Props["title"])
+	}
+
+	js, _ := json.Marshal(page.Props["auth"])
+	if string(js) != `{"user":{"name":"alice"}}` {
+		t.Errorf("expected auth without token, got: %s", js)
+	}
+}
+
+func TestRenderWithNestedLazyProps(t *testing.T) {
+	type team struct {
+		Name    string `json:"name"`
+		Members int    `json:"members"`
+	}
+
+	for _, except := range []string{"", "auth.team"} {
+		i := New("http://inertia-go.test", "", "")
+
+		var evaluated []string
+
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set(HeaderInertia, "true")
+
+		if except != "" {
+			r.Header.Set(HeaderPartialComponent, "test/component")
+			r.Header.Set(HeaderPartialExcept, except)
+		}
+
+		ctx := i.WithAlwaysProp(r.Context(), "auth", func() any {
+			return map[string]any{
+				"user": func() any {
+					evaluated = append(evaluated, "user")
+
+					return map[string]any{"name": "alice", "permissions": []string{"edit"}}
+				},
+				"notifications": func() any {
+					evaluated = append(evaluated, "notifications")
+
+					return 3
+				},
+				"team": &team{Name: "core", Members: 4},
+			}
+		})
+		r = r.WithContext(ctx)
+		w := httptest.NewRecorder()
+
+		err := i.Render(w, r, "test/component", map[string]any{
+			"title":    "Test",
+			"settings": map[string]any{"theme": "dark", "locale": "en"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var page Page
+
+		err = json.NewDecoder(w.Result().Body).Decode(&page)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		slices.Sort(evaluated)
+
+		if !slices.Equal(evaluated, []string{"notifications", "user"}) {
+			t.Errorf("except %q expected user and notifications to be evaluated, got: %v", except, evaluated)
+		}
+
+		expected := `{"notifications":3,"team":{"members":4,"name":"core"},"user":{"name":"alice","permissions":["edit"]}}`
+		if except != "" {
+			expected = `{"notifications":3,"user":{"name":"alice","permissions":["edit"]}}`
+		}
+
+		js, _ := json.Marshal(page.Props["auth"])
+		if string(js) != expected {
+			t.Errorf("except %q expected: %s, got: %s", except, expected, js)
+		}
+	}
+}
+
 func TestLocation(t *testing.T) {
 	url := "http://inertia-go.test"
 	externalUrl := "http://dashboard.inertia-go.test"
@@ -1369,3 +1972,46 @@ func TestLocation(t *testing.T) {
 		t.Errorf("expected empty body, got: %s", body)
 	}
 }
+
+func TestBack(t *testing.T) {
+	i := New("http://inertia-go.test", "", "")
+
+	for _, tt := range []struct {
+		method   string
+		inertia  bool
+		referer  string
+		location string
+		status   int
+	}{
+		{http.MethodGet, false, "http://inertia-go.test/users?page=2", "http://inertia-go.test/users?page=2", http.StatusFound},
+		{http.MethodPost, false, "http://inertia-go.test/users", "http://inertia-go.test/users", http.StatusFound},
+		{http.MethodPost, true, "http://inertia-go.test/users", "http://inertia-go.test/users", http.StatusSeeOther},
+		{http.MethodGet, true, "http://inertia-go.test/users", "http://inertia-go.test/users", http.StatusFound},
+		{http.MethodPut, true, "http://evil.test/users", "/dashboard", http.StatusSeeOther},
+		{http.MethodPost, true, "https://inertia-go.test/users", "/dashboard", http.StatusSeeOther},
+		{http.MethodPost, true, "", "/dashboard", http.StatusSeeOther},
+	} {
+		r := httptest.NewRequest(tt.method, "/users", nil)
+		if tt.inertia {
+			r.Header.Set(HeaderInertia, "true")
+		}
+
+		if tt.referer != "" {
+			r.Header.Set("Referer", tt.referer)
+		}
+
+		w := httptest.NewRecorder()
+
+		i.Back(w, r, "/dashboard")
+
+		resp := w.Result()
+
+		if resp.StatusCode != tt.status {
+			t.Errorf("expected status code: %d, got: %d", tt.status, resp.StatusCode)
+		}
+
+		if loc := resp.Header.Get("Location"); loc != tt.location {
+			t.Errorf("expected location: %s, got: %s", tt.location, loc)
+		}
+	}
+}
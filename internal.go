@@ -1,105 +1,190 @@
 package inertia
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"html/template"
 	"maps"
 	"net/http"
+	"net/url"
+	"path"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 )
 
-func (i *Inertia) isSsrEnabled() bool {
-	return i.ssrURL != "" && i.ssrClient != nil
+type lazyProp func(context.Context) (any, error)
+
+func lazyFunc(value func() any) lazyProp {
+	return func(context.Context) (any, error) {
+		return value(), nil
+	}
 }
 
-func (i *Inertia) ssr(ctx context.Context, page *Page) (*Ssr, error) {
-	body, err := json.Marshal(page)
-	if err != nil {
-		return nil, err
+func (c *config) isSsrEnabled() bool {
+	return c.ssrRenderer != nil
+}
+
+func (c *config) isSsrExcluded(component string) bool {
+	for _, pattern := range c.ssrExcludes {
+		if matchComponent(pattern, component) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchComponent matches with path.Match, except that a trailing /* or
+// /** matches every component nested below the prefix.
+func matchComponent(pattern, component string) bool {
+	ok, _ := path.Match(pattern, component)
+	if ok {
+		return true
 	}
 
-	req, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodPost,
-		i.ssrURL,
-		bytes.NewBuffer(body),
-	)
+	prefix, found := strings.CutSuffix(pattern, "/**")
+	if !found {
+		prefix, found = strings.CutSuffix(pattern, "/*")
+	}
+
+	if !found {
+		return false
+	}
+
+	n := strings.Count(prefix, "/") + 1
+	segments := strings.SplitN(component, "/", n+1)
+
+	if len(segments) <= n {
+		return false
+	}
+
+	ok, _ = path.Match(prefix, strings.Join(segments[:n], "/"))
+
+	return ok
+}
+
+func (c *config) resolveVersion(r *http.Request) string {
+	if c.versionFunc != nil {
+		return c.versionFunc(r)
+	}
+
+	return c.version
+}
+
+func isSameOrigin(baseURL, targetURL string) bool {
+	base, err := url.Parse(baseURL)
+	if err != nil || base.Host == "" {
+		return false
+	}
+
+	target, err := url.Parse(targetURL)
 	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(base.Scheme, target.Scheme) && strings.EqualFold(base.Host, target.Host)
+}
+
+func (c *config) ssr(ctx context.Context, page *Page) (*Ssr, error) {
+	key, ssr, ok := c.ssrCache.get(page)
+	if ok {
+		return ssr, nil
+	}
+
+	if !c.ssrBreaker.allow() {
+		return nil, ErrSsrCircuitOpen
+	}
+
+	ssr, err := c.ssrWithRetries(ctx, page)
+	if err != nil && ctx.Err() != nil {
+		c.ssrBreaker.release()
+
 		return nil, err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	c.ssrBreaker.record(err)
 
-	resp, err := i.ssrClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
 
-	defer resp.Body.Close()
+	c.ssrCache.set(key, ssr)
+
+	return ssr, nil
+}
+
+func (c *config) ssrWithRetries(ctx context.Context, page *Page) (*Ssr, error) {
+	for attempt := 0; ; attempt++ {
+		ssr, err := c.ssrRender(ctx, page)
+		if err == nil || attempt >= c.ssrOptions.Retries || ctx.Err() != nil {
+			return ssr, err
+		}
 
-	if resp.StatusCode >= 400 {
-		return nil, ErrBadSsrStatusCode
+		select {
+		case <-time.After(c.ssrOptions.RetryBackoff << attempt):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
 	}
+}
 
-	var ssr Ssr
+func (c *config) ssrRender(ctx context.Context, page *Page) (*Ssr, error) {
+	if c.ssrOptions.Timeout > 0 {
+		var cancel context.CancelFunc
 
-	err = json.NewDecoder(resp.Body).Decode(&ssr)
-	if err != nil {
-		return nil, err
+		ctx, cancel = context.WithTimeout(ctx, c.ssrOptions.Timeout)
+		defer cancel()
 	}
 
-	return &ssr, nil
+	return c.ssrRenderer.Render(ctx, page)
 }
 
-func (i *Inertia) createRootTemplate() (*template.Template, error) {
-	if i.parsedTemplate != nil {
-		return i.parsedTemplate, nil
+func (c *config) createRootTemplate() (*template.Template, error) {
+	if tpl := c.parsedTemplate.template.Load(); tpl != nil {
+		return tpl, nil
+	}
+
+	c.parsedTemplate.mu.Lock()
+	defer c.parsedTemplate.mu.Unlock()
+
+	if tpl := c.parsedTemplate.template.Load(); tpl != nil {
+		return tpl, nil
 	}
 
-	ts := template.New(filepath.Base(i.rootTemplate)).Funcs(i.sharedFuncMap)
+	ts := template.New(filepath.Base(c.rootTemplate)).Funcs(c.sharedFuncMap)
 
 	var tpl *template.Template
 	var err error
 
-	if i.templateFS != nil {
-		tpl, err = ts.ParseFS(i.templateFS, i.rootTemplate)
+	if c.templateFS != nil {
+		tpl, err = ts.ParseFS(c.templateFS, c.rootTemplate)
 	} else {
-		tpl, err = ts.ParseFiles(i.rootTemplate)
+		tpl, err = ts.ParseFiles(c.rootTemplate)
 	}
 
 	if err != nil {
 		return nil, err
 	}
 
-	i.parsedTemplate = tpl
+	c.parsedTemplate.template.Store(tpl)
 
-	return i.parsedTemplate, nil
+	return tpl, nil
 }
 
-func (i *Inertia) createViewData(r *http.Request) (map[string]any, error) {
-	contextViewData, err := contextGet[map[string]any](r.Context(), contextKeyViewData)
-	if err != nil {
-		return nil, err
-	}
-
-	viewData := make(map[string]any, len(i.sharedViewData))
-	maps.Copy(viewData, i.sharedViewData)
-	maps.Copy(viewData, contextViewData)
+func (c *config) createViewData(rt *runtime) map[string]any {
+	viewData := make(map[string]any, len(c.sharedViewData)+len(rt.response.viewData))
+	maps.Copy(viewData, c.sharedViewData)
+	maps.Copy(viewData, rt.response.viewData)
 
-	return viewData, nil
+	return viewData
 }
 
-func (i *Inertia) createBaseProps(r *http.Request, rt *runtime, page *Page) error {
-	contextProps, err := contextGet[map[string]any](r.Context(), contextKeyProps)
-	if err != nil {
-		return err
-	}
-
+func (c *config) createBaseProps(r *http.Request, rt *runtime, page *Page) error {
 	baseProps := make(map[string]any)
-	maps.Copy(baseProps, i.sharedProps)
-	maps.Copy(baseProps, contextProps)
+	maps.Copy(baseProps, c.sharedProps)
+	maps.Copy(baseProps, rt.response.props)
 	maps.Copy(baseProps, rt.props)
 
 	for key, value := range baseProps {
@@ -117,21 +202,41 @@ func (i *Inertia) createBaseProps(r *http.Request, rt *runtime, page *Page) erro
 	return nil
 }
 
-func (i *Inertia) createOptionalProps(r *http.Request, rt *runtime, page *Page) error {
-	return i.createMainProps(r, rt, page, contextKeyOptionalProps)
-}
+func (c *config) createOptionalProps(r *http.Request, rt *runtime, page *Page) error {
+	if !rt.isPartial {
+		return nil
+	}
 
-func (i *Inertia) createAlwaysProps(r *http.Request, rt *runtime, page *Page) error {
-	return i.createMainProps(r, rt, page, contextKeyAlwaysProps)
+	for key, value := range rt.response.optionalProps {
+		_, ok := rt.except[key]
+		if ok {
+			continue
+		}
+
+		_, ok = rt.only[key]
+		if ok {
+			page.Props[key] = value
+		}
+	}
+
+	return nil
 }
 
-func (i *Inertia) createDeferredProps(r *http.Request, rt *runtime, page *Page) error {
-	deferredProps, err := contextGet[map[string]contextDeferredProp](r.Context(), contextKeyDeferredProps)
-	if err != nil {
-		return err
+func (c *config) createAlwaysProps(r *http.Request, rt *runtime, page *Page) error {
+	for key, value := range rt.response.alwaysProps {
+		_, ok := rt.except[key]
+		if ok {
+			continue
+		}
+
+		page.Props[key] = value
 	}
 
-	for key, value := range deferredProps {
+	return nil
+}
+
+func (c *config) createDeferredProps(r *http.Request, rt *runtime, page *Page) error {
+	for key, value := range rt.response.deferredProps {
 		_, ok := rt.except[key]
 		if ok {
 			continue
@@ -140,7 +245,7 @@ func (i *Inertia) createDeferredProps(r *http.Request, rt *runtime, page *Page)
 		if rt.isPartial {
 			_, ok = rt.only[key]
 			if len(rt.only) == 0 || ok {
-				page.Props[key] = value.Value()
+				page.Props[key] = value.Value
 			}
 		} else {
 			if page.DeferredProps == nil {
@@ -154,25 +259,20 @@ func (i *Inertia) createDeferredProps(r *http.Request, rt *runtime, page *Page)
 	return nil
 }
 
-func (i *Inertia) createMergeProps(r *http.Request, rt *runtime, page *Page) error {
-	return i.createMergeableProps(r, rt, page, contextKeyMergeProps)
+func (c *config) createMergeProps(r *http.Request, rt *runtime, page *Page) error {
+	return c.createMergeableProps(rt, page, rt.response.mergeProps, &page.MergeProps)
 }
 
-func (i *Inertia) createDeepMergeProps(r *http.Request, rt *runtime, page *Page) error {
-	return i.createMergeableProps(r, rt, page, contextKeyDeepMergeProps)
+func (c *config) createDeepMergeProps(r *http.Request, rt *runtime, page *Page) error {
+	return c.createMergeableProps(rt, page, rt.response.deepMergeProps, &page.DeepMergeProps)
 }
 
-func (i *Inertia) createPrependProps(r *http.Request, rt *runtime, page *Page) error {
-	return i.createMergeableProps(r, rt, page, contextKeyPrependProps)
+func (c *config) createPrependProps(r *http.Request, rt *runtime, page *Page) error {
+	return c.createMergeableProps(rt, page, rt.response.prependProps, &page.PrependProps)
 }
 
-func (i *Inertia) createScrollProps(r *http.Request, rt *runtime, page *Page) error {
-	scrollProps, err := contextGet[map[string]ScrollPageProp](r.Context(), contextKeyScrollProp)
-	if err != nil {
-		return err
-	}
-
-	for key, prop := range scrollProps {
+func (c *config) createScrollProps(r *http.Request, rt *runtime, page *Page) error {
+	for key, prop := range rt.response.scrollProps {
 		if page.ScrollProps == nil {
 			page.ScrollProps = make(map[string]ScrollPageProp)
 		}
@@ -188,17 +288,35 @@ func (i *Inertia) createScrollProps(r *http.Request, rt *runtime, page *Page) er
 	return nil
 }
 
-func (i *Inertia) createOnceProps(r *http.Request, rt *runtime, page *Page) error {
-	return i.createMainProps(r, rt, page, contextKeyOnceProps)
-}
+func (c *config) createOnceProps(r *http.Request, rt *runtime, page *Page) error {
+	for key, value := range rt.response.onceProps {
+		_, ok := rt.except[key]
+		if ok {
+			continue
+		}
 
-func (i *Inertia) createOnceModifiers(r *http.Request, rt *runtime, page *Page) error {
-	onceModifiers, err := contextGet[map[string]OncePageProp](r.Context(), contextKeyOnce)
-	if err != nil {
-		return err
+		if page.OnceProps == nil {
+			page.OnceProps = make(map[string]OncePageProp)
+		}
+
+		page.OnceProps[key] = OncePageProp{Prop: key}
+
+		_, ok = rt.exceptOnce[key]
+		if ok {
+			continue
+		}
+
+		_, ok = rt.only[key]
+		if len(rt.only) == 0 || ok {
+			page.Props[key] = value
+		}
 	}
 
-	for key, prop := range onceModifiers {
+	return nil
+}
+
+func (c *config) createOnceModifiers(r *http.Request, rt *runtime, page *Page) error {
+	for key, prop := range rt.response.once {
 		if page.OnceProps == nil {
 			page.OnceProps = make(map[string]OncePageProp)
 		}
@@ -214,58 +332,58 @@ func (i *Inertia) createOnceModifiers(r *http.Request, rt *runtime, page *Page)
 	return nil
 }
 
-func (i *Inertia) createErrorProps(r *http.Request, _ *runtime, page *Page) error {
-	contextErrors, err := contextGet[map[string]any](r.Context(), contextKeyErrors)
-	if err != nil {
-		return err
+func (c *config) createErrorProps(r *http.Request, rt *runtime, page *Page) error {
+	errors := make(map[string]any)
+
+	if sess := sessionFromContext(r.Context()); sess != nil {
+		maps.Copy(errors, sess.consume().Errors)
 	}
 
-	errors, ok := page.Props["errors"].(map[string]any)
-	if !ok {
-		errors = make(map[string]any)
+	inlineErrors, ok := page.Props["errors"].(map[string]any)
+	if ok {
+		maps.Copy(errors, inlineErrors)
 	}
 
-	maps.Copy(errors, contextErrors)
+	maps.Copy(errors, rt.response.errors)
+
+	if rt.errorBag != "" {
+		maps.Copy(errors, rt.response.errorBags[rt.errorBag])
+
+		if len(errors) > 0 {
+			page.Props["errors"] = map[string]any{rt.errorBag: errors}
+		} else {
+			page.Props["errors"] = errors
+		}
+
+		return nil
+	}
+
+	for bag, bagErrors := range rt.response.errorBags {
+		errors[bag] = bagErrors
+	}
 
 	page.Props["errors"] = errors
 
 	return nil
 }
 
-func (i *Inertia) createMainProps(r *http.Request, rt *runtime, page *Page, key contextKey) error {
-	props, err := contextGet[map[string]func() any](r.Context(), key)
-	if err != nil {
-		return err
-	}
-
-	for k, value := range props {
-		_, ok := rt.except[k]
+func (c *config) createMergeableProps(rt *runtime, page *Page, props map[string]contextMergeableProp, keys *[]string) error {
+	for key, prop := range props {
+		_, ok := rt.except[key]
 		if ok {
 			continue
 		}
 
-		switch key {
-		case contextKeyOptionalProps:
-			if rt.isPartial {
-				_, ok = rt.only[k]
-				if ok {
-					page.Props[k] = value()
-				}
-			}
-		case contextKeyAlwaysProps:
-			page.Props[k] = value()
-		case contextKeyOnceProps:
-			if page.OnceProps == nil {
-				page.OnceProps = make(map[string]OncePageProp)
-			}
+		_, ok = rt.only[key]
+		if len(rt.only) == 0 || ok {
+			page.Props[key] = prop.Value
 
-			page.OnceProps[k] = OncePageProp{Prop: k}
+			_, resetting := rt.reset[key]
+			if !resetting {
+				*keys = append(*keys, key)
 
-			_, exceptOnce := rt.exceptOnce[k]
-			if !exceptOnce {
-				_, ok = rt.only[k]
-				if len(rt.only) == 0 || ok {
-					page.Props[k] = value()
+				for _, m := range prop.MatchOn {
+					page.MatchPropsOn = append(page.MatchPropsOn, key+"."+m)
 				}
 			}
 		}
@@ -274,39 +392,108 @@ func (i *Inertia) createMainProps(r *http.Request, rt *runtime, page *Page, key
 	return nil
 }
 
-func (i *Inertia) createMergeableProps(r *http.Request, rt *runtime, page *Page, key contextKey) error {
-	props, err := contextGet[map[string]contextMergeableProp](r.Context(), key)
-	if err != nil {
-		return err
+func (c *config) resolveProps(ctx context.Context, page *Page) error {
+	var keys []string
+
+	for key, value := range page.Props {
+		if _, ok := value.(lazyProp); ok {
+			keys = append(keys, key)
+		}
 	}
 
-	for k, prop := range props {
-		_, ok := rt.except[k]
-		if ok {
-			continue
+	slices.Sort(keys)
+
+	if !c.concurrent || len(keys) < 2 {
+		for _, key := range keys {
+			value, err := page.Props[key].(lazyProp)(ctx)
+			if err != nil {
+				return &PropError{Component: page.Component, Key: key, Err: err}
+			}
+
+			page.Props[key] = value
 		}
 
-		_, ok = rt.only[k]
-		if len(rt.only) == 0 || ok {
-			page.Props[k] = prop.Value()
+		return nil
+	}
 
-			_, resetting := rt.reset[k]
-			if !resetting {
-				switch key {
-				case contextKeyMergeProps:
-					page.MergeProps = append(page.MergeProps, k)
-				case contextKeyDeepMergeProps:
-					page.DeepMergeProps = append(page.DeepMergeProps, k)
-				case contextKeyPrependProps:
-					page.PrependProps = append(page.PrependProps, k)
-				}
+	limit := c.concurrency
+	if limit <= 0 || limit > len(keys) {
+		limit = len(keys)
+	}
 
-				for _, m := range prop.MatchOn {
-					page.MatchPropsOn = append(page.MatchPropsOn, k+"."+m)
-				}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	values := make([]any, len(keys))
+	panics := make([]any, len(keys))
+	sem := make(chan struct{}, limit)
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+
+	for idx, key := range keys {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		value := page.Props[key].(lazyProp)
+
+		wg.Go(func() {
+			defer func() {
+				panics[idx] = recover()
+				<-sem
+			}()
+
+			v, err := value(ctx)
+			if err != nil {
+				once.Do(func() {
+					firstErr = &PropError{Component: page.Component, Key: key, Err: err}
+					cancel()
+				})
+
+				return
 			}
+
+			values[idx] = v
+		})
+	}
+
+	wg.Wait()
+
+	for _, p := range panics {
+		if p != nil {
+			panic(p)
 		}
 	}
 
+	if firstErr != nil {
+		return firstErr
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	for idx, key := range keys {
+		page.Props[key] = values[idx]
+	}
+
 	return nil
 }
+
+func sortPage(page *Page) {
+	for _, group := range page.DeferredProps {
+		slices.Sort(group)
+	}
+
+	slices.Sort(page.MergeProps)
+	slices.Sort(page.DeepMergeProps)
+	slices.Sort(page.PrependProps)
+	slices.Sort(page.MatchPropsOn)
+}
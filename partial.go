@@ -0,0 +1,283 @@
+package inertia
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+type propPath struct {
+	all      bool
+	children map[string]*propPath
+}
+
+func newPropPath() *propPath {
+	return &propPath{
+		children: make(map[string]*propPath),
+	}
+}
+
+func (p *propPath) add(path string) {
+	node := p
+
+	for segment := range strings.SplitSeq(path, ".") {
+		child, ok := node.children[segment]
+		if !ok {
+			child = newPropPath()
+			node.children[segment] = child
+		}
+
+		node = child
+	}
+
+	node.all = true
+}
+
+func filterProps(ctx context.Context, rt *runtime, page *Page) error {
+	for key, node := range rt.onlyTree.children {
+		value, ok := page.Props[key]
+		if !ok || node.all {
+			continue
+		}
+
+		picked, err := pickPath(ctx, value, node)
+		if err != nil {
+			return &PropError{Component: page.Component, Key: key, Err: err}
+		}
+
+		page.Props[key] = picked
+	}
+
+	for key, node := range rt.exceptTree.children {
+		value, ok := page.Props[key]
+		if !ok || node.all {
+			continue
+		}
+
+		omitted, err := omitPath(ctx, value, node)
+		if err != nil {
+			return &PropError{Component: page.Component, Key: key, Err: err}
+		}
+
+		page.Props[key] = omitted
+	}
+
+	return nil
+}
+
+func pickPath(ctx context.Context, value any, node *propPath) (any, error) {
+	value, err := resolveNested(ctx, value)
+	if err != nil || node.all {
+		return value, err
+	}
+
+	m, ok, err := nestedMap(value)
+	if err != nil || !ok {
+		return value, err
+	}
+
+	picked := make(map[string]any, len(node.children))
+
+	for key, child := range node.children {
+		v, ok := m[key]
+		if !ok {
+			continue
+		}
+
+		picked[key], err = pickPath(ctx, v, child)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return picked, nil
+}
+
+func omitPath(ctx context.Context, value any, node *propPath) (any, error) {
+	value, err := resolveNested(ctx, value)
+	if err != nil {
+		return nil, err
+	}
+
+	m, ok, err := nestedMap(value)
+	if err != nil || !ok {
+		return value, err
+	}
+
+	omitted := maps.Clone(m)
+
+	for key, child := range node.children {
+		if child.all {
+			delete(omitted, key)
+
+			continue
+		}
+
+		v, ok := omitted[key]
+		if !ok {
+			continue
+		}
+
+		omitted[key], err = omitPath(ctx, v, child)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return omitted, nil
+}
+
+func resolveNestedProps(ctx context.Context, page *Page) error {
+	for key, value := range page.Props {
+		resolved, changed, err := resolveDeep(ctx, value)
+		if err != nil {
+			return &PropError{Component: page.Component, Key: key, Err: err}
+		}
+
+		if changed {
+			page.Props[key] = resolved
+		}
+	}
+
+	return nil
+}
+
+// resolveDeep resolves the lazy values nested in maps and slices. The
+// containers are copied on write, so shared props are never mutated.
+func resolveDeep(ctx context.Context, value any) (any, bool, error) {
+	resolved, err := resolveNested(ctx, value)
+	if err != nil {
+		return nil, false, err
+	}
+
+	changed := isNestedLazy(value)
+
+	switch v := resolved.(type) {
+	case map[string]any:
+		var c map[string]any
+
+		for key, item := range v {
+			r, ok, err := resolveDeep(ctx, item)
+			if err != nil {
+				return nil, false, err
+			}
+
+			if !ok {
+				continue
+			}
+
+			if c == nil {
+				c = maps.Clone(v)
+			}
+
+			c[key] = r
+		}
+
+		if c != nil {
+			return c, true, nil
+		}
+	case []any:
+		var c []any
+
+		for idx, item := range v {
+			r, ok, err := resolveDeep(ctx, item)
+			if err != nil {
+				return nil, false, err
+			}
+
+			if !ok {
+				continue
+			}
+
+			if c == nil {
+				c = slices.Clone(v)
+			}
+
+			c[idx] = r
+		}
+
+		if c != nil {
+			return c, true, nil
+		}
+	}
+
+	return resolved, changed, nil
+}
+
+func isNestedLazy(value any) bool {
+	switch value.(type) {
+	case lazyProp, func(context.Context) (any, error), func() any:
+		return true
+	}
+
+	return false
+}
+
+func resolveNested(ctx context.Context, value any) (any, error) {
+	switch v := value.(type) {
+	case lazyProp:
+		return v(ctx)
+	case func(context.Context) (any, error):
+		return v(ctx)
+	case func() any:
+		return v(), nil
+	}
+
+	return value, nil
+}
+
+func nestedMap(value any) (map[string]any, bool, error) {
+	if m, ok := value.(map[string]any); ok {
+		return m, true, nil
+	}
+
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, false, nil
+		}
+
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, false, nil
+		}
+
+		m := make(map[string]any, rv.Len())
+
+		for iter := rv.MapRange(); iter.Next(); {
+			m[iter.Key().String()] = iter.Value().Interface()
+		}
+
+		return m, true, nil
+	case reflect.Struct:
+		// Structs are walked by their JSON shape, so json tags and
+		// custom marshalers are respected.
+		js, err := json.Marshal(value)
+		if err != nil {
+			return nil, false, err
+		}
+
+		var m map[string]any
+
+		// UseNumber keeps integers above 2^53 intact.
+		dec := json.NewDecoder(bytes.NewReader(js))
+		dec.UseNumber()
+
+		err = dec.Decode(&m)
+		if err != nil {
+			return nil, false, nil
+		}
+
+		return m, true, nil
+	}
+
+	return nil, false, nil
+}
@@ -0,0 +1,224 @@
+package inertia
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"time"
+)
+
+// SsrBalancer type.
+type SsrBalancer int
+
+const (
+	// RoundRobin balancer.
+	RoundRobin SsrBalancer = iota
+
+	// LeastInFlight balancer.
+	LeastInFlight
+)
+
+// SsrBackend type.
+type SsrBackend struct {
+	URL      string
+	Healthy  bool
+	InFlight int
+	Failures int
+}
+
+const defaultBackendCooldown = 5 * time.Second
+
+// HTTPSsrRenderer type.
+type HTTPSsrRenderer struct {
+	balancer SsrBalancer
+	backends []*ssrBackend
+	next     atomic.Uint64
+	cooldown atomic.Int64
+}
+
+type ssrBackend struct {
+	url       string
+	endpoint  string
+	client    *http.Client
+	inFlight  atomic.Int64
+	failures  atomic.Int64
+	downUntil atomic.Int64
+}
+
+// NewHTTPSsrRenderer function.
+func NewHTTPSsrRenderer(balancer SsrBalancer, ssrURLs []string, client ...*http.Client) *HTTPSsrRenderer {
+	if len(ssrURLs) == 0 {
+		return nil
+	}
+
+	cl := &http.Client{}
+	if len(client) > 0 && client[0] != nil {
+		cl = client[0]
+	}
+
+	p := &HTTPSsrRenderer{
+		balancer: balancer,
+		backends: make([]*ssrBackend, 0, len(ssrURLs)),
+	}
+
+	for _, u := range ssrURLs {
+		p.backends = append(p.backends, newSsrBackend(u, cl))
+	}
+
+	return p
+}
+
+func newSsrBackend(rawURL string, client *http.Client) *ssrBackend {
+	b := &ssrBackend{
+		url:      rawURL,
+		endpoint: rawURL,
+		client:   client,
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "unix" {
+		return b
+	}
+
+	socket := u.Path
+	dialer := &net.Dialer{}
+
+	unixClient := *client
+	unixClient.Transport = &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", socket)
+		},
+	}
+
+	b.endpoint = "http://unix/render"
+	b.client = &unixClient
+
+	return b
+}
+
+// Render function.
+func (p *HTTPSsrRenderer) Render(ctx context.Context, page *Page) (*Ssr, error) {
+	body, err := json.Marshal(page)
+	if err != nil {
+		return nil, err
+	}
+
+	backend := p.pick()
+
+	backend.inFlight.Add(1)
+	defer backend.inFlight.Add(-1)
+
+	ssr, err := backend.render(ctx, body)
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		backend.record(err, time.Duration(p.cooldown.Load()))
+	}
+
+	return ssr, err
+}
+
+func (p *HTTPSsrRenderer) pick() *ssrBackend {
+	now := time.Now().UnixNano()
+	offset := int(p.next.Add(1) - 1)
+
+	var picked *ssrBackend
+
+	for _, healthyOnly := range []bool{true, false} {
+		for n := range p.backends {
+			b := p.backends[(offset+n)%len(p.backends)]
+
+			if healthyOnly && !b.healthy(now) {
+				continue
+			}
+
+			if p.balancer == RoundRobin {
+				return b
+			}
+
+			if picked == nil || b.inFlight.Load() < picked.inFlight.Load() {
+				picked = b
+			}
+		}
+
+		if picked != nil {
+			return picked
+		}
+	}
+
+	return picked
+}
+
+// Backends function.
+func (p *HTTPSsrRenderer) Backends() []SsrBackend {
+	now := time.Now().UnixNano()
+	status := make([]SsrBackend, 0, len(p.backends))
+
+	for _, b := range p.backends {
+		status = append(status, SsrBackend{
+			URL:      b.url,
+			Healthy:  b.healthy(now),
+			InFlight: int(b.inFlight.Load()),
+			Failures: int(b.failures.Load()),
+		})
+	}
+
+	return status
+}
+
+func (b *ssrBackend) healthy(now int64) bool {
+	return b.downUntil.Load() <= now
+}
+
+func (b *ssrBackend) record(err error, cooldown time.Duration) {
+	if err == nil {
+		b.failures.Store(0)
+		b.downUntil.Store(0)
+
+		return
+	}
+
+	if cooldown <= 0 {
+		cooldown = defaultBackendCooldown
+	}
+
+	b.failures.Add(1)
+	b.downUntil.Store(time.Now().Add(cooldown).UnixNano())
+}
+
+func (b *ssrBackend) render(ctx context.Context, body []byte) (*Ssr, error) {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		b.endpoint,
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, ErrBadSsrStatusCode
+	}
+
+	var ssr Ssr
+
+	err = json.NewDecoder(resp.Body).Decode(&ssr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ssr, nil
+}
@@ -0,0 +1,164 @@
+package inertia
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func renderSsrTestPage(t *testing.T, i *Inertia) string {
+	t.Helper()
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+
+	err := i.Render(w, r, "test/component", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return w.Body.String()
+}
+
+func TestEnableSsrWithUnixSocket(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "ssr.sock")
+
+	ln, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Skip("unix sockets are not supported:", err)
+	}
+
+	var path string
+
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write([]byte(`{"head":[],"body":"<div id=\"unix\"></div>"}`))
+	})}
+
+	go srv.Serve(ln)
+	defer srv.Close()
+
+	i := newSsrTestInertia("unix://"+socket, ssrTestTemplate)
+
+	body := renderSsrTestPage(t, i)
+	if body != `<div id="unix"></div>` {
+		t.Errorf("unexpected body: %s", body)
+	}
+
+	if path != "/render" {
+		t.Errorf("expected: /render, got: %s", path)
+	}
+}
+
+func TestEnableSsrPoolRoundRobin(t *testing.T) {
+	var first, second atomic.Int32
+
+	ts1 := newSsrTestServer(t, func() { first.Add(1) })
+	ts2 := newSsrTestServer(t, func() { second.Add(1) })
+
+	i := newSsrTestInertia("", ssrTestTemplate)
+	i.EnableSsrPool(RoundRobin, []string{ts1.URL, ts2.URL})
+
+	for range 4 {
+		renderSsrTestPage(t, i)
+	}
+
+	if first.Load() != 2 || second.Load() != 2 {
+		t.Errorf("expected 2 calls per backend, got: %d and %d", first.Load(), second.Load())
+	}
+}
+
+func TestEnableSsrPoolLeastInFlight(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	var slow, fast atomic.Int32
+
+	ts1 := newSsrTestServer(t, func() {
+		if slow.Add(1) == 1 {
+			close(started)
+		}
+
+		<-release
+	})
+
+	ts2 := newSsrTestServer(t, func() { fast.Add(1) })
+
+	i := newSsrTestInertia("", ssrTestTemplate)
+	i.EnableSsrPool(LeastInFlight, []string{ts1.URL, ts2.URL})
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		renderSsrTestPage(t, i)
+	}()
+
+	<-started
+
+	for range 3 {
+		renderSsrTestPage(t, i)
+	}
+
+	if fast.Load() != 3 {
+		t.Errorf("expected 3 calls to the idle backend, got: %d", fast.Load())
+	}
+
+	backends := i.SsrBackends()
+	if backends[0].InFlight != 1 {
+		t.Errorf("expected 1 request in flight, got: %d", backends[0].InFlight)
+	}
+
+	close(release)
+	<-done
+
+	if slow.Load() != 1 {
+		t.Errorf("expected 1 call to the busy backend, got: %d", slow.Load())
+	}
+}
+
+func TestEnableSsrPoolHealth(t *testing.T) {
+	var broken, healthy atomic.Int32
+
+	ts1 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		broken.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts1.Close()
+
+	ts2 := newSsrTestServer(t, func() { healthy.Add(1) })
+
+	i := newSsrTestInertia("", ssrTestTemplate)
+	i.EnableSsrPool(RoundRobin, []string{ts1.URL, ts2.URL})
+	i.SetSsrOptions(SsrOptions{Retries: 1, BackendCooldown: time.Minute})
+
+	for range 4 {
+		body := renderSsrTestPage(t, i)
+		if body != `<div id="app"></div>` {
+			t.Errorf("unexpected body: %s", body)
+		}
+	}
+
+	if broken.Load() != 1 {
+		t.Errorf("expected the broken backend to be skipped after 1 call, got: %d", broken.Load())
+	}
+
+	if healthy.Load() != 4 {
+		t.Errorf("expected 4 calls to the healthy backend, got: %d", healthy.Load())
+	}
+
+	backends := i.SsrBackends()
+
+	if backends[0].Healthy || backends[0].Failures != 1 {
+		t.Errorf("expected the first backend to be unhealthy, got: %+v", backends[0])
+	}
+
+	if !backends[1].Healthy || backends[1].Failures != 0 {
+		t.Errorf("expected the second backend to be healthy, got: %+v", backends[1])
+	}
+}
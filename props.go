@@ -0,0 +1,299 @@
+package inertia
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+type structPropKind int
+
+const (
+	structPropBase structPropKind = iota
+	structPropOptional
+	structPropAlways
+	structPropDeferred
+	structPropMerge
+	structPropDeepMerge
+	structPropPrepend
+	structPropOnce
+)
+
+type structProp struct {
+	index   []int
+	key     string
+	kind    structPropKind
+	group   string
+	matchOn []string
+	once    bool
+}
+
+var (
+	contextType = reflect.TypeFor[context.Context]()
+	errorType   = reflect.TypeFor[error]()
+
+	structPropsCache sync.Map
+)
+
+// RenderStruct function.
+func (i *Inertia) RenderStruct(w http.ResponseWriter, r *http.Request, component string, v any) error {
+	ctx, props, err := i.withStructProps(r.Context(), v)
+	if err != nil {
+		return err
+	}
+
+	return i.Render(w, r.WithContext(ctx), component, props)
+}
+
+func (i *Inertia) withStructProps(ctx context.Context, v any) (context.Context, map[string]any, error) {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return ctx, nil, nil
+		}
+
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return ctx, nil, fmt.Errorf("%w: %T is not a struct", ErrInvalidStructProps, v)
+	}
+
+	fields, err := cachedStructProps(value.Type())
+	if err != nil {
+		return ctx, nil, err
+	}
+
+	props := make(map[string]any)
+
+	for _, field := range fields {
+		fieldValue, ok := fieldByIndex(value, field.index)
+		if !ok {
+			continue
+		}
+
+		if field.kind == structPropBase {
+			if fieldValue.Kind() == reflect.Func {
+				props[field.key] = lazyValue(fieldValue)
+			} else {
+				props[field.key] = fieldValue.Interface()
+			}
+
+			continue
+		}
+
+		lazy := lazyValue(fieldValue)
+
+		switch field.kind {
+		case structPropOptional:
+			ctx = i.WithOptionalPropContext(ctx, field.key, lazy)
+		case structPropAlways:
+			ctx = i.WithAlwaysPropContext(ctx, field.key, lazy)
+		case structPropDeferred:
+			ctx = i.WithDeferredPropContext(ctx, field.key, lazy, field.group)
+		case structPropMerge:
+			ctx = i.WithMergePropContext(ctx, field.key, lazy, field.matchOn...)
+		case structPropDeepMerge:
+			ctx = i.WithDeepMergePropContext(ctx, field.key, lazy, field.matchOn...)
+		case structPropPrepend:
+			ctx = i.WithPrependPropContext(ctx, field.key, lazy, field.matchOn...)
+		case structPropOnce:
+			ctx = i.WithOncePropContext(ctx, field.key, lazy)
+		}
+
+		if field.once {
+			ctx = i.WithOnce(ctx, field.key)
+		}
+	}
+
+	return ctx, props, nil
+}
+
+func cachedStructProps(t reflect.Type) ([]structProp, error) {
+	if fields, ok := structPropsCache.Load(t); ok {
+		return fields.([]structProp), nil
+	}
+
+	fields, err := parseStructProps(t, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	actual, _ := structPropsCache.LoadOrStore(t, fields)
+
+	return actual.([]structProp), nil
+}
+
+func parseStructProps(t reflect.Type, index []int) ([]structProp, error) {
+	var fields []structProp
+
+	for idx := range t.NumField() {
+		f := t.Field(idx)
+		tag, hasTag := f.Tag.Lookup("inertia")
+
+		if tag == "-" {
+			continue
+		}
+
+		fieldIndex := append(append([]int(nil), index...), idx)
+
+		if f.Anonymous && !hasTag {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+
+			if ft.Kind() == reflect.Struct {
+				embedded, err := parseStructProps(ft, fieldIndex)
+				if err != nil {
+					return nil, err
+				}
+
+				fields = append(fields, embedded...)
+
+				continue
+			}
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+
+		if name, _, _ := strings.Cut(tag, ","); name == "" && f.Tag.Get("json") == "-" {
+			continue
+		}
+
+		field, err := parseStructProp(f, tag)
+		if err != nil {
+			return nil, err
+		}
+
+		field.index = fieldIndex
+
+		if f.Type.Kind() == reflect.Func && !isLazyFuncType(f.Type) {
+			return nil, fmt.Errorf("%w: field %s has unsupported func type %s", ErrInvalidStructProps, f.Name, f.Type)
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+func parseStructProp(f reflect.StructField, tag string) (structProp, error) {
+	name, options, _ := strings.Cut(tag, ",")
+
+	field := structProp{
+		key: name,
+	}
+
+	if field.key == "" {
+		jsonName, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if jsonName != "" {
+			field.key = jsonName
+		} else {
+			field.key = f.Name
+		}
+	}
+
+	if options == "" {
+		return field, nil
+	}
+
+	for option := range strings.SplitSeq(options, ",") {
+		option, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+
+		switch option {
+		case "optional":
+			field.kind = structPropOptional
+		case "always":
+			field.kind = structPropAlways
+		case "deferred":
+			field.kind = structPropDeferred
+			field.group = value
+		case "merge":
+			field.kind = structPropMerge
+		case "deepMerge":
+			field.kind = structPropDeepMerge
+		case "prepend":
+			field.kind = structPropPrepend
+		case "matchOn":
+			field.matchOn = append(field.matchOn, value)
+		case "once":
+			field.once = true
+		default:
+			return field, fmt.Errorf("%w: field %s has unknown option %q", ErrInvalidStructProps, f.Name, option)
+		}
+	}
+
+	if field.once && field.kind == structPropBase {
+		field.kind = structPropOnce
+		field.once = false
+	}
+
+	return field, nil
+}
+
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for idx, i := range index {
+		if idx > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+
+			v = v.Elem()
+		}
+
+		v = v.Field(i)
+	}
+
+	return v, true
+}
+
+func isLazyFuncType(t reflect.Type) bool {
+	if t.IsVariadic() || t.NumIn() > 1 || t.NumOut() < 1 || t.NumOut() > 2 {
+		return false
+	}
+
+	if t.NumIn() == 1 && t.In(0) != contextType {
+		return false
+	}
+
+	return t.NumOut() == 1 || t.Out(1) == errorType
+}
+
+func lazyValue(v reflect.Value) lazyProp {
+	if v.Kind() != reflect.Func {
+		value := v.Interface()
+
+		return func(context.Context) (any, error) {
+			return value, nil
+		}
+	}
+
+	if v.IsNil() {
+		return func(context.Context) (any, error) {
+			return nil, nil
+		}
+	}
+
+	t := v.Type()
+
+	return func(ctx context.Context) (any, error) {
+		var in []reflect.Value
+		if t.NumIn() == 1 {
+			in = []reflect.Value{reflect.ValueOf(ctx)}
+		}
+
+		out := v.Call(in)
+
+		if len(out) == 2 && !out[1].IsNil() {
+			return nil, out[1].Interface().(error)
+		}
+
+		return out[0].Interface(), nil
+	}
+}
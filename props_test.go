@@ -0,0 +1,187 @@
+package inertia
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+type testPageMeta struct {
+	Locale string `json:"locale"`
+}
+
+type testUsersProps struct {
+	testPageMeta
+	Title   string                                        `json:"title"`
+	Users   func() any                                    `inertia:"users,deferred=sidebar"`
+	Stats   func(context.Context) ([]int, error)          `inertia:"stats,optional"`
+	Feed    func() []string                               `inertia:"feed,merge,matchOn=id"`
+	Plans   func(context.Context) (map[string]int, error) `inertia:"plans,once"`
+	Count   int                                           `inertia:"count,always"`
+	Ignored string                                        `inertia:"-"`
+	hidden  string
+}
+
+func renderTestPage(t *testing.T, r *http.Request, render func(http.ResponseWriter, *http.Request) error) Page {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+
+	err := render(w, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var page Page
+
+	err = json.NewDecoder(w.Result().Body).Decode(&page)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return page
+}
+
+func TestRenderStruct(t *testing.T) {
+	i := New("http://inertia-go.test", "", "")
+
+	props := testUsersProps{
+		testPageMeta: testPageMeta{Locale: "en"},
+		Title:        "Users",
+		Users:        func() any { return []string{"alice"} },
+		Stats:        func(context.Context) ([]int, error) { return []int{1, 2}, nil },
+		Feed:         func() []string { return []string{"a"} },
+		Plans:        func(context.Context) (map[string]int, error) { return map[string]int{"pro": 10}, nil },
+		Count:        2,
+		Ignored:      "ignored",
+		hidden:       "hidden",
+	}
+
+	for _, partial := range []string{"", "users,stats"} {
+		newRequest := func() *http.Request {
+			r := httptest.NewRequest(http.MethodGet, "/users", nil)
+			r.Header.Set(HeaderInertia, "true")
+
+			if partial != "" {
+				r.Header.Set(HeaderPartialComponent, "Users/Index")
+				r.Header.Set(HeaderPartialOnly, partial)
+			}
+
+			return r
+		}
+
+		got := renderTestPage(t, newRequest(), func(w http.ResponseWriter, r *http.Request) error {
+			return i.RenderStruct(w, r, "Users/Index", &props)
+		})
+
+		expected := renderTestPage(t, newRequest(), func(w http.ResponseWriter, r *http.Request) error {
+			ctx := i.WithDeferredProp(r.Context(), "users", props.Users, "sidebar")
+			ctx = i.WithOptionalPropContext(ctx, "stats", func(ctx context.Context) (any, error) { return props.Stats(ctx) })
+			ctx = i.WithMergeProp(ctx, "feed", func() any { return props.Feed() }, "id")
+			ctx = i.WithOncePropContext(ctx, "plans", func(ctx context.Context) (any, error) { return props.Plans(ctx) })
+			ctx = i.WithAlwaysProp(ctx, "count", func() any { return props.Count })
+
+			return i.Render(w, r.WithContext(ctx), "Users/Index", map[string]any{
+				"locale": "en",
+				"title":  "Users",
+			})
+		})
+
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("partial %q expected: %+v, got: %+v", partial, expected, got)
+		}
+	}
+}
+
+func TestRenderStructWithJSONIgnoredField(t *testing.T) {
+	i := New("http://inertia-go.test", "", "")
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(HeaderInertia, "true")
+
+	page := renderTestPage(t, r, func(w http.ResponseWriter, r *http.Request) error {
+		return i.RenderStruct(w, r, "Users/Index", struct {
+			Name   string `json:"name"`
+			Secret string `json:"-"`
+			Dash   string `json:"-,"`
+			Token  string `json:"-" inertia:"token"`
+		}{
+			Name:   "alice",
+			Secret: "hunter2",
+			Dash:   "dash",
+			Token:  "token",
+		})
+	})
+
+	if _, ok := page.Props["Secret"]; ok {
+		t.Error("expected the json ignored field to be absent")
+	}
+
+	if page.Props["name"] != "alice" || page.Props["-"] != "dash" || page.Props["token"] != "token" {
+		t.Errorf("unexpected props: %v", page.Props)
+	}
+}
+
+func TestRenderStructWithError(t *testing.T) {
+	errQuery := errors.New("query failed")
+
+	i := New("http://inertia-go.test", "", "")
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(HeaderInertia, "true")
+	w := httptest.NewRecorder()
+
+	err := i.RenderStruct(w, r, "Users/Index", struct {
+		Users func(context.Context) (any, error) `inertia:"users"`
+	}{
+		Users: func(context.Context) (any, error) { return nil, errQuery },
+	})
+	if !errors.Is(err, errQuery) {
+		t.Errorf("expected: %v, got: %v", errQuery, err)
+	}
+}
+
+func TestRenderStructWithInvalidProps(t *testing.T) {
+	i := New("http://inertia-go.test", "", "")
+
+	for _, v := range []any{
+		"not a struct",
+		struct {
+			Users func(string) any `inertia:"users"`
+		}{},
+		struct {
+			Users []string `inertia:"users,unknown"`
+		}{},
+	} {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set(HeaderInertia, "true")
+		w := httptest.NewRecorder()
+
+		err := i.RenderStruct(w, r, "Users/Index", v)
+		if !errors.Is(err, ErrInvalidStructProps) {
+			t.Errorf("expected: %v, got: %v", ErrInvalidStructProps, err)
+		}
+	}
+}
+
+func TestCachedStructProps(t *testing.T) {
+	first, err := cachedStructProps(reflect.TypeFor[testUsersProps]())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := cachedStructProps(reflect.TypeFor[testUsersProps]())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(first) != 7 {
+		t.Errorf("expected 7 fields, got: %d", len(first))
+	}
+
+	if &first[0] != &second[0] {
+		t.Error("expected cached fields to be reused")
+	}
+}
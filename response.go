@@ -0,0 +1,261 @@
+package inertia
+
+import (
+	"context"
+	"maps"
+	"net/http"
+)
+
+// Response type.
+//
+// A Response is an immutable, request-scoped builder. Every With method
+// returns a modified copy and leaves the receiver untouched, so a derived
+// context never leaks props into its parent or siblings.
+type Response struct {
+	viewData         map[string]any
+	props            map[string]any
+	optionalProps    map[string]lazyProp
+	alwaysProps      map[string]lazyProp
+	deferredProps    map[string]contextDeferredProp
+	mergeProps       map[string]contextMergeableProp
+	deepMergeProps   map[string]contextMergeableProp
+	prependProps     map[string]contextMergeableProp
+	scrollProps      map[string]ScrollPageProp
+	onceProps        map[string]lazyProp
+	once             map[string]OncePageProp
+	errors           map[string]any
+	errorBags        map[string]map[string]any
+	flash            map[string]any
+	clearHistory     bool
+	encryptHistory   bool
+	preserveFragment bool
+	withoutSsr       bool
+}
+
+// FromContext function.
+func FromContext(ctx context.Context) *Response {
+	res, ok := ctx.Value(contextKeyResponse).(*Response)
+	if !ok || res == nil {
+		return &Response{}
+	}
+
+	return res
+}
+
+// FromRequest function.
+func FromRequest(r *http.Request) *Response {
+	return FromContext(r.Context())
+}
+
+// NewContext function.
+func (res *Response) NewContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKeyResponse, res)
+}
+
+// Attach function.
+func (res *Response) Attach(r *http.Request) *http.Request {
+	return r.WithContext(res.NewContext(r.Context()))
+}
+
+// WithViewData function.
+func (res *Response) WithViewData(key string, value any) *Response {
+	c := *res
+	c.viewData = cloneSet(res.viewData, key, value)
+
+	return &c
+}
+
+// WithProp function.
+func (res *Response) WithProp(key string, value any) *Response {
+	c := *res
+	c.props = cloneSet(res.props, key, value)
+
+	return &c
+}
+
+// WithOptionalProp function.
+func (res *Response) WithOptionalProp(key string, value func() any) *Response {
+	return res.WithOptionalPropContext(key, lazyFunc(value))
+}
+
+// WithOptionalPropContext function.
+func (res *Response) WithOptionalPropContext(key string, value func(context.Context) (any, error)) *Response {
+	c := *res
+	c.optionalProps = cloneSet(res.optionalProps, key, lazyProp(value))
+
+	return &c
+}
+
+// WithAlwaysProp function.
+func (res *Response) WithAlwaysProp(key string, value func() any) *Response {
+	return res.WithAlwaysPropContext(key, lazyFunc(value))
+}
+
+// WithAlwaysPropContext function.
+func (res *Response) WithAlwaysPropContext(key string, value func(context.Context) (any, error)) *Response {
+	c := *res
+	c.alwaysProps = cloneSet(res.alwaysProps, key, lazyProp(value))
+
+	return &c
+}
+
+// WithDeferredProp function.
+func (res *Response) WithDeferredProp(key string, value func() any, group ...string) *Response {
+	return res.WithDeferredPropContext(key, lazyFunc(value), group...)
+}
+
+// WithDeferredPropContext function.
+func (res *Response) WithDeferredPropContext(key string, value func(context.Context) (any, error), group ...string) *Response {
+	g := "default"
+	if len(group) > 0 && group[0] != "" {
+		g = group[0]
+	}
+
+	c := *res
+	c.deferredProps = cloneSet(res.deferredProps, key, contextDeferredProp{Group: g, Value: value})
+
+	return &c
+}
+
+// WithMergeProp function.
+func (res *Response) WithMergeProp(key string, value func() any, matchOn ...string) *Response {
+	return res.WithMergePropContext(key, lazyFunc(value), matchOn...)
+}
+
+// WithMergePropContext function.
+func (res *Response) WithMergePropContext(key string, value func(context.Context) (any, error), matchOn ...string) *Response {
+	c := *res
+	c.mergeProps = cloneSet(res.mergeProps, key, contextMergeableProp{MatchOn: matchOn, Value: value})
+
+	return &c
+}
+
+// WithDeepMergeProp function.
+func (res *Response) WithDeepMergeProp(key string, value func() any, matchOn ...string) *Response {
+	return res.WithDeepMergePropContext(key, lazyFunc(value), matchOn...)
+}
+
+// WithDeepMergePropContext function.
+func (res *Response) WithDeepMergePropContext(key string, value func(context.Context) (any, error), matchOn ...string) *Response {
+	c := *res
+	c.deepMergeProps = cloneSet(res.deepMergeProps, key, contextMergeableProp{MatchOn: matchOn, Value: value})
+
+	return &c
+}
+
+// WithPrependProp function.
+func (res *Response) WithPrependProp(key string, value func() any, matchOn ...string) *Response {
+	return res.WithPrependPropContext(key, lazyFunc(value), matchOn...)
+}
+
+// WithPrependPropContext function.
+func (res *Response) WithPrependPropContext(key string, value func(context.Context) (any, error), matchOn ...string) *Response {
+	c := *res
+	c.prependProps = cloneSet(res.prependProps, key, contextMergeableProp{MatchOn: matchOn, Value: value})
+
+	return &c
+}
+
+// WithScrollProp function.
+func (res *Response) WithScrollProp(key string, prop ScrollPageProp) *Response {
+	c := *res
+	c.scrollProps = cloneSet(res.scrollProps, key, prop)
+
+	return &c
+}
+
+// WithOnceProp function.
+func (res *Response) WithOnceProp(key string, value func() any) *Response {
+	return res.WithOncePropContext(key, lazyFunc(value))
+}
+
+// WithOncePropContext function.
+func (res *Response) WithOncePropContext(key string, value func(context.Context) (any, error)) *Response {
+	c := *res
+	c.onceProps = cloneSet(res.onceProps, key, lazyProp(value))
+
+	return &c
+}
+
+// WithOnce function.
+func (res *Response) WithOnce(key string, prop ...OncePageProp) *Response {
+	p := OncePageProp{}
+	if len(prop) > 0 {
+		p = prop[0]
+	}
+
+	p.Prop = key
+
+	c := *res
+	c.once = cloneSet(res.once, key, p)
+
+	return &c
+}
+
+// WithErrorProp function.
+func (res *Response) WithErrorProp(key string, value any) *Response {
+	c := *res
+	c.errors = cloneSet(res.errors, key, value)
+
+	return &c
+}
+
+// WithErrorBagProp function.
+func (res *Response) WithErrorBagProp(bag, key string, value any) *Response {
+	c := *res
+	c.errorBags = cloneSet(res.errorBags, bag, cloneSet(res.errorBags[bag], key, value))
+
+	return &c
+}
+
+// WithFlash function.
+func (res *Response) WithFlash(data map[string]any) *Response {
+	flash := make(map[string]any, len(res.flash)+len(data))
+	maps.Copy(flash, res.flash)
+	maps.Copy(flash, data)
+
+	c := *res
+	c.flash = flash
+
+	return &c
+}
+
+// WithClearHistory function.
+func (res *Response) WithClearHistory() *Response {
+	c := *res
+	c.clearHistory = true
+
+	return &c
+}
+
+// WithEncryptHistory function.
+func (res *Response) WithEncryptHistory() *Response {
+	c := *res
+	c.encryptHistory = true
+
+	return &c
+}
+
+// WithPreserveFragment function.
+func (res *Response) WithPreserveFragment() *Response {
+	c := *res
+	c.preserveFragment = true
+
+	return &c
+}
+
+// WithoutSsr function.
+func (res *Response) WithoutSsr() *Response {
+	c := *res
+	c.withoutSsr = true
+
+	return &c
+}
+
+func cloneSet[T any](m map[string]T, key string, value T) map[string]T {
+	c := make(map[string]T, len(m)+1)
+	maps.Copy(c, m)
+	c[key] = value
+
+	return c
+}
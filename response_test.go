@@ -0,0 +1,136 @@
+package inertia
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestFromRequest(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	res := FromRequest(r)
+	if res == nil {
+		t.Fatal("expected: response, got: nil")
+	}
+
+	if len(res.props) != 0 {
+		t.Errorf("expected empty props, got: %v", res.props)
+	}
+
+	r = res.WithProp("user", "test-user").Attach(r)
+
+	if FromRequest(r).props["user"] != "test-user" {
+		t.Errorf("expected: test-user, got: %v", FromRequest(r).props["user"])
+	}
+}
+
+func TestResponseCopyOnWrite(t *testing.T) {
+	base := FromContext(context.TODO()).WithProp("shared", true)
+
+	left := base.WithProp("left", 1).WithErrorBagProp("createUser", "name", "Required")
+	right := base.WithProp("right", 2).WithErrorBagProp("createUser", "email", "Invalid")
+
+	if !reflect.DeepEqual(base.props, map[string]any{"shared": true}) {
+		t.Errorf("expected base props to be untouched, got: %v", base.props)
+	}
+
+	if !reflect.DeepEqual(left.props, map[string]any{"shared": true, "left": 1}) {
+		t.Errorf("unexpected left props: %v", left.props)
+	}
+
+	if !reflect.DeepEqual(right.props, map[string]any{"shared": true, "right": 2}) {
+		t.Errorf("unexpected right props: %v", right.props)
+	}
+
+	if !reflect.DeepEqual(left.errorBags, map[string]map[string]any{"createUser": {"name": "Required"}}) {
+		t.Errorf("unexpected left error bags: %v", left.errorBags)
+	}
+
+	if !reflect.DeepEqual(right.errorBags, map[string]map[string]any{"createUser": {"email": "Invalid"}}) {
+		t.Errorf("unexpected right error bags: %v", right.errorBags)
+	}
+}
+
+func TestWithPropSiblingContexts(t *testing.T) {
+	i := New("", "", "")
+
+	parent := i.WithProp(context.TODO(), "shared", true)
+	left := i.WithProp(parent, "left", 1)
+	right := i.WithProp(parent, "right", 2)
+
+	if _, ok := FromContext(parent).props["left"]; ok {
+		t.Error("expected left prop not to leak into parent")
+	}
+
+	if _, ok := FromContext(right).props["left"]; ok {
+		t.Error("expected left prop not to leak into sibling")
+	}
+
+	if _, ok := FromContext(left).props["right"]; ok {
+		t.Error("expected right prop not to leak into sibling")
+	}
+}
+
+func TestWithPropConcurrent(t *testing.T) {
+	i := New("", "", "")
+
+	parent := i.WithProp(context.TODO(), "shared", true)
+
+	var wg sync.WaitGroup
+
+	for n := range 100 {
+		wg.Go(func() {
+			ctx := i.WithProp(parent, "n", n)
+			ctx = i.WithFlash(ctx, map[string]any{"n": n})
+
+			if FromContext(ctx).props["n"] != n {
+				t.Errorf("expected: %d, got: %v", n, FromContext(ctx).props["n"])
+			}
+		})
+	}
+
+	wg.Wait()
+}
+
+func TestRenderWithResponse(t *testing.T) {
+	i := New("http://inertia-go.test", "", "")
+
+	r := httptest.NewRequest(http.MethodGet, "/users", nil)
+	r.Header.Set(HeaderInertia, "true")
+
+	r = FromRequest(r).
+		WithProp("title", "Users").
+		WithDeferredProp("users", func() any { return []string{"alice"} }, "sidebar").
+		WithErrorProp("name", "Required").
+		WithFlash(map[string]any{"status": "saved"}).
+		WithEncryptHistory().
+		Attach(r)
+
+	page := renderTestPage(t, r, func(w http.ResponseWriter, r *http.Request) error {
+		return i.Render(w, r, "Users/Index", nil)
+	})
+
+	if page.Props["title"] != "Users" {
+		t.Errorf("expected: Users, got: %v", page.Props["title"])
+	}
+
+	if !reflect.DeepEqual(page.DeferredProps, map[string][]string{"sidebar": {"users"}}) {
+		t.Errorf("unexpected deferred props: %v", page.DeferredProps)
+	}
+
+	if !reflect.DeepEqual(page.Props["errors"], map[string]any{"name": "Required"}) {
+		t.Errorf("unexpected errors: %v", page.Props["errors"])
+	}
+
+	if page.Flash["status"] != "saved" {
+		t.Errorf("expected: saved, got: %v", page.Flash["status"])
+	}
+
+	if !page.EncryptHistory {
+		t.Error("expected encryptHistory to be true")
+	}
+}
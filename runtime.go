@@ -7,11 +7,15 @@ import (
 
 type runtime struct {
 	isPartial  bool
+	errorBag   string
 	props      map[string]any
 	only       map[string]struct{}
 	except     map[string]struct{}
+	onlyTree   *propPath
+	exceptTree *propPath
 	exceptOnce map[string]struct{}
 	reset      map[string]struct{}
+	response   *Response
 }
 
 func newRuntime(r *http.Request, component string, props map[string]any) *runtime {
@@ -19,8 +23,12 @@ func newRuntime(r *http.Request, component string, props map[string]any) *runtim
 		props:      props,
 		only:       make(map[string]struct{}),
 		except:     make(map[string]struct{}),
+		onlyTree:   newPropPath(),
+		exceptTree: newPropPath(),
 		exceptOnce: make(map[string]struct{}),
 		reset:      make(map[string]struct{}),
+		errorBag:   r.Header.Get(HeaderErrorBag),
+		response:   FromRequest(r),
 	}
 
 	if r.Header.Get(HeaderPartialComponent) == component {
@@ -28,7 +36,10 @@ func newRuntime(r *http.Request, component string, props map[string]any) *runtim
 			rt.isPartial = true
 
 			for value := range strings.SplitSeq(partial, ",") {
-				rt.only[value] = struct{}{}
+				key, _, _ := strings.Cut(value, ".")
+
+				rt.only[key] = struct{}{}
+				rt.onlyTree.add(value)
 			}
 		}
 
@@ -36,7 +47,11 @@ func newRuntime(r *http.Request, component string, props map[string]any) *runtim
 			rt.isPartial = true
 
 			for value := range strings.SplitSeq(partialExcept, ",") {
-				rt.except[value] = struct{}{}
+				if !strings.Contains(value, ".") {
+					rt.except[value] = struct{}{}
+				}
+
+				rt.exceptTree.add(value)
 			}
 		}
 	}
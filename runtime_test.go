@@ -134,6 +134,51 @@ func TestNewRuntimeWithReset(t *testing.T) {
 	}
 }
 
+func TestNewRuntimeWithErrorBag(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	r.Header.Set(HeaderErrorBag, "createUser")
+
+	rt := newRuntime(r, "test/component", nil)
+
+	if rt.errorBag != "createUser" {
+		t.Errorf("expected: createUser, got: %s", rt.errorBag)
+	}
+}
+
+func TestNewRuntimeWithNestedPartial(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(HeaderPartialComponent, "test/component")
+	r.Header.Set(HeaderPartialOnly, "auth.user.permissions,auth.team,title")
+	r.Header.Set(HeaderPartialExcept, "settings.secret")
+
+	rt := newRuntime(r, "test/component", nil)
+
+	if len(rt.only) != 2 {
+		t.Errorf("expected 2 only entries, got: %d", len(rt.only))
+	}
+
+	if _, ok := rt.only["auth"]; !ok {
+		t.Error("expected auth in only")
+	}
+
+	if len(rt.except) != 0 {
+		t.Errorf("expected empty except, got: %d", len(rt.except))
+	}
+
+	auth := rt.onlyTree.children["auth"]
+	if auth == nil || auth.all || len(auth.children) != 2 {
+		t.Fatalf("expected auth node with 2 children, got: %+v", auth)
+	}
+
+	if !auth.children["user"].children["permissions"].all {
+		t.Error("expected auth.user.permissions to be selected")
+	}
+
+	if !rt.exceptTree.children["settings"].children["secret"].all {
+		t.Error("expected settings.secret to be excluded")
+	}
+}
+
 func TestNewRuntimeWithDifferentComponent(t *testing.T) {
 	r := httptest.NewRequest(http.MethodGet, "/", nil)
 	r.Header.Set(HeaderPartialComponent, "other/component")
@@ -0,0 +1,263 @@
+package inertia
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"maps"
+	"net/http"
+	"sync"
+)
+
+// SessionData type.
+type SessionData struct {
+	Flash  map[string]any `json:"flash,omitempty"`
+	Errors map[string]any `json:"errors,omitempty"`
+}
+
+// IsEmpty function.
+func (d *SessionData) IsEmpty() bool {
+	return d == nil || (len(d.Flash) == 0 && len(d.Errors) == 0)
+}
+
+// SessionStore interface.
+type SessionStore interface {
+	Load(r *http.Request) (*SessionData, error)
+	Save(w http.ResponseWriter, r *http.Request, data *SessionData) error
+	Clear(w http.ResponseWriter, r *http.Request) error
+}
+
+// CookieSessionStore type.
+type CookieSessionStore struct {
+	Name     string
+	Path     string
+	Domain   string
+	MaxAge   int
+	Secure   bool
+	SameSite http.SameSite
+	aead     cipher.AEAD
+}
+
+// NewCookieSessionStore function.
+func NewCookieSessionStore(name string, key []byte) (*CookieSessionStore, error) {
+	if len(key) < 32 {
+		return nil, ErrInvalidSessionKey
+	}
+
+	hash := sha256.Sum256(key)
+
+	block, err := aes.NewCipher(hash[:])
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CookieSessionStore{
+		Name:     name,
+		Path:     "/",
+		SameSite: http.SameSiteLaxMode,
+		aead:     aead,
+	}, nil
+}
+
+// Load function.
+func (s *CookieSessionStore) Load(r *http.Request) (*SessionData, error) {
+	cookie, err := r.Cookie(s.Name)
+	if err != nil {
+		return &SessionData{}, nil
+	}
+
+	sealed, err := base64.RawURLEncoding.DecodeString(cookie.Value)
+	if err != nil || len(sealed) < s.aead.NonceSize() {
+		return &SessionData{}, ErrInvalidSession
+	}
+
+	nonce, ciphertext := sealed[:s.aead.NonceSize()], sealed[s.aead.NonceSize():]
+
+	plaintext, err := s.aead.Open(nil, nonce, ciphertext, []byte(s.Name))
+	if err != nil {
+		return &SessionData{}, ErrInvalidSession
+	}
+
+	var data SessionData
+
+	err = json.Unmarshal(plaintext, &data)
+	if err != nil {
+		return &SessionData{}, ErrInvalidSession
+	}
+
+	return &data, nil
+}
+
+// Save function.
+func (s *CookieSessionStore) Save(w http.ResponseWriter, _ *http.Request, data *SessionData) error {
+	plaintext, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, s.aead.NonceSize())
+
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return err
+	}
+
+	value := base64.RawURLEncoding.EncodeToString(s.aead.Seal(nonce, nonce, plaintext, []byte(s.Name)))
+	if len(value) > 4000 {
+		return ErrSessionTooLarge
+	}
+
+	http.SetCookie(w, s.cookie(value, s.MaxAge))
+
+	return nil
+}
+
+// Clear function.
+func (s *CookieSessionStore) Clear(w http.ResponseWriter, _ *http.Request) error {
+	http.SetCookie(w, s.cookie("", -1))
+
+	return nil
+}
+
+func (s *CookieSessionStore) cookie(value string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     s.Name,
+		Value:    value,
+		Path:     s.Path,
+		Domain:   s.Domain,
+		MaxAge:   maxAge,
+		Secure:   s.Secure,
+		HttpOnly: true,
+		SameSite: s.SameSite,
+	}
+}
+
+// SessionErrorHandler type.
+type SessionErrorHandler func(r *http.Request, err error)
+
+func logSessionError(r *http.Request, err error) {
+	slog.ErrorContext(
+		r.Context(),
+		"inertia: session store failed",
+		slog.String("url", r.RequestURI),
+		slog.Any("error", err),
+	)
+}
+
+type session struct {
+	mu       sync.Mutex
+	store    SessionStore
+	onError  SessionErrorHandler
+	incoming *SessionData
+	outgoing *SessionData
+	consumed bool
+}
+
+func newSession(w http.ResponseWriter, r *http.Request, store SessionStore, onError SessionErrorHandler) *session {
+	if onError == nil {
+		onError = logSessionError
+	}
+
+	incoming, err := store.Load(r)
+	if err != nil {
+		onError(r, err)
+	}
+
+	if err != nil || incoming == nil {
+		incoming = &SessionData{}
+	}
+
+	if errors.Is(err, ErrInvalidSession) {
+		err = store.Clear(w, r)
+		if err != nil {
+			onError(r, err)
+		}
+	}
+
+	return &session{
+		store:    store,
+		onError:  onError,
+		incoming: incoming,
+		outgoing: &SessionData{},
+	}
+}
+
+func sessionFromContext(ctx context.Context) *session {
+	sess, _ := ctx.Value(contextKeySession).(*session)
+
+	return sess
+}
+
+func (s *session) consume() *SessionData {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.consumed = true
+
+	return s.incoming
+}
+
+func (s *session) flash(data map[string]any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.outgoing.Flash == nil {
+		s.outgoing.Flash = make(map[string]any)
+	}
+
+	maps.Copy(s.outgoing.Flash, data)
+}
+
+func (s *session) flashErrors(errors map[string]any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.outgoing.Errors == nil {
+		s.outgoing.Errors = make(map[string]any)
+	}
+
+	maps.Copy(s.outgoing.Errors, errors)
+}
+
+func (s *session) reflash(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.incoming.IsEmpty() {
+		return
+	}
+
+	err := s.store.Save(w, r, s.incoming)
+	if err != nil {
+		s.onError(r, err)
+	}
+}
+
+func (s *session) commit(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var err error
+
+	switch {
+	case !s.outgoing.IsEmpty():
+		err = s.store.Save(w, r, s.outgoing)
+	case s.consumed && !s.incoming.IsEmpty():
+		err = s.store.Clear(w, r)
+	}
+
+	if err != nil {
+		s.onError(r, err)
+	}
+}
@@ -0,0 +1,314 @@
+package inertia
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var testSessionKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestNewCookieSessionStoreWithShortKey(t *testing.T) {
+	_, err := NewCookieSessionStore("inertia_session", []byte("short"))
+	if err != ErrInvalidSessionKey {
+		t.Errorf("expected: %v, got: %v", ErrInvalidSessionKey, err)
+	}
+}
+
+func TestCookieSessionStore(t *testing.T) {
+	store, err := NewCookieSessionStore("inertia_session", testSessionKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	err = store.Save(w, r, &SessionData{
+		Flash:  map[string]any{"success": "created"},
+		Errors: map[string]any{"email": "Invalid"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 {
+		t.Fatalf("expected 1 cookie, got: %d", len(cookies))
+	}
+
+	if !cookies[0].HttpOnly {
+		t.Error("expected cookie to be http only")
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	r.AddCookie(cookies[0])
+
+	data, err := store.Load(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if data.Flash["success"] != "created" {
+		t.Errorf("expected: created, got: %v", data.Flash["success"])
+	}
+
+	if data.Errors["email"] != "Invalid" {
+		t.Errorf("expected: Invalid, got: %v", data.Errors["email"])
+	}
+}
+
+func TestCookieSessionStoreWithTamperedCookie(t *testing.T) {
+	store, err := NewCookieSessionStore("inertia_session", testSessionKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.AddCookie(&http.Cookie{Name: "inertia_session", Value: "dGFtcGVyZWQtdmFsdWUtdGhhdC1pcy1sb25n"})
+
+	data, err := store.Load(r)
+	if err != ErrInvalidSession {
+		t.Errorf("expected: %v, got: %v", ErrInvalidSession, err)
+	}
+
+	if !data.IsEmpty() {
+		t.Errorf("expected empty session data, got: %v", data)
+	}
+}
+
+func TestFlashWithoutSession(t *testing.T) {
+	i := New("http://inertia-go.test", "", "")
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+
+	err := i.Flash(r.Context(), map[string]any{"success": "created"})
+	if err != ErrSessionNotStarted {
+		t.Errorf("expected: %v, got: %v", ErrSessionNotStarted, err)
+	}
+}
+
+func TestSessionSurvivesRedirect(t *testing.T) {
+	store, err := NewCookieSessionStore("inertia_session", testSessionKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	i := New("http://inertia-go.test", "", "")
+	i.SetSessionStore(store)
+
+	m := i.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			i.Flash(r.Context(), map[string]any{"success": "created"})
+			i.FlashErrors(r.Context(), map[string]any{"email": "Invalid"})
+			http.Redirect(w, r, "/", http.StatusFound)
+
+			return
+		}
+
+		err := i.Render(w, r, "test/component", nil)
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	r.Header.Set(HeaderInertia, "true")
+	w := httptest.NewRecorder()
+
+	m.ServeHTTP(w, r)
+
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 {
+		t.Fatalf("expected 1 cookie, got: %d", len(cookies))
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(HeaderInertia, "true")
+	r.AddCookie(cookies[0])
+	w = httptest.NewRecorder()
+
+	m.ServeHTTP(w, r)
+
+	var page Page
+
+	err = json.NewDecoder(w.Result().Body).Decode(&page)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if page.Flash["success"] != "created" {
+		t.Errorf("expected: created, got: %v", page.Flash["success"])
+	}
+
+	errors, ok := page.Props["errors"].(map[string]any)
+	if !ok {
+		t.Fatal("expected: errors map in props, got: empty value")
+	}
+
+	if errors["email"] != "Invalid" {
+		t.Errorf("expected: Invalid, got: %v", errors["email"])
+	}
+
+	cookies = w.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].MaxAge >= 0 {
+		t.Errorf("expected session cookie to be cleared, got: %v", cookies)
+	}
+}
+
+func TestSessionReflashOnVersionConflict(t *testing.T) {
+	store, err := NewCookieSessionStore("inertia_session", testSessionKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	i := New("http://inertia-go.test", "", "abc123")
+	i.SetSessionStore(store)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	err = store.Save(w, r, &SessionData{Flash: map[string]any{"success": "created"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := i.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected handler not to be called")
+	}))
+
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(HeaderInertia, "true")
+	r.Header.Set(HeaderVersion, "old")
+	r.AddCookie(w.Result().Cookies()[0])
+	w = httptest.NewRecorder()
+
+	m.ServeHTTP(w, r)
+
+	resp := w.Result()
+
+	if resp.StatusCode != http.StatusConflict {
+		t.Errorf("expected status code: %d, got: %d", http.StatusConflict, resp.StatusCode)
+	}
+
+	cookies := resp.Cookies()
+	if len(cookies) != 1 {
+		t.Fatalf("expected 1 cookie, got: %d", len(cookies))
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	r.AddCookie(cookies[0])
+
+	data, err := store.Load(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if data.Flash["success"] != "created" {
+		t.Errorf("expected: created, got: %v", data.Flash["success"])
+	}
+}
+
+func TestSessionErrorHandler(t *testing.T) {
+	store, err := NewCookieSessionStore("inertia_session", testSessionKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var reported error
+
+	i := New("http://inertia-go.test", "", "")
+	i.SetSessionStore(store, func(r *http.Request, err error) {
+		reported = err
+	})
+
+	m := i.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i.Flash(r.Context(), map[string]any{"message": strings.Repeat("x", 4000)})
+		http.Redirect(w, r, "/", http.StatusFound)
+	}))
+
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	r.Header.Set(HeaderInertia, "true")
+	w := httptest.NewRecorder()
+
+	m.ServeHTTP(w, r)
+
+	if !errors.Is(reported, ErrSessionTooLarge) {
+		t.Errorf("expected: %v, got: %v", ErrSessionTooLarge, reported)
+	}
+}
+
+func TestSessionClearsTamperedCookie(t *testing.T) {
+	store, err := NewCookieSessionStore("inertia_session", testSessionKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	i := New("http://inertia-go.test", "", "")
+	i.SetSessionStore(store)
+
+	m := i.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.AddCookie(&http.Cookie{Name: "inertia_session", Value: "dGFtcGVyZWQtdmFsdWUtdGhhdC1pcy1sb25n"})
+	w := httptest.NewRecorder()
+
+	m.ServeHTTP(w, r)
+
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].MaxAge >= 0 {
+		t.Errorf("expected the tampered cookie to be cleared, got: %v", cookies)
+	}
+}
+
+type failingSessionStore struct {
+	err     error
+	cleared bool
+}
+
+func (s *failingSessionStore) Load(*http.Request) (*SessionData, error) {
+	return nil, s.err
+}
+
+func (s *failingSessionStore) Save(http.ResponseWriter, *http.Request, *SessionData) error {
+	return nil
+}
+
+func (s *failingSessionStore) Clear(http.ResponseWriter, *http.Request) error {
+	s.cleared = true
+
+	return nil
+}
+
+func TestSessionReportsLoadError(t *testing.T) {
+	errStore := errors.New("store is down")
+	store := &failingSessionStore{err: errStore}
+
+	var reported error
+
+	i := New("http://inertia-go.test", "", "")
+	i.SetSessionStore(store, func(r *http.Request, err error) {
+		reported = err
+	})
+
+	m := i.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+
+	m.ServeHTTP(w, r)
+
+	if !errors.Is(reported, errStore) {
+		t.Errorf("expected: %v, got: %v", errStore, reported)
+	}
+
+	if store.cleared {
+		t.Error("expected the session not to be cleared")
+	}
+}
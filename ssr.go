@@ -1,7 +1,50 @@
 package inertia
 
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
 // Ssr type.
 type Ssr struct {
 	Head []string `json:"head"`
 	Body string   `json:"body"`
 }
+
+// SsrRenderer type.
+type SsrRenderer interface {
+	Render(ctx context.Context, page *Page) (*Ssr, error)
+}
+
+// SsrRendererFunc type.
+type SsrRendererFunc func(ctx context.Context, page *Page) (*Ssr, error)
+
+// Render function.
+func (f SsrRendererFunc) Render(ctx context.Context, page *Page) (*Ssr, error) {
+	return f(ctx, page)
+}
+
+// SsrOptions type.
+type SsrOptions struct {
+	Timeout          time.Duration
+	Retries          int
+	RetryBackoff     time.Duration
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+	BackendCooldown  time.Duration
+}
+
+// SsrErrorHandler type.
+type SsrErrorHandler func(r *http.Request, page *Page, err error)
+
+func logSsrError(r *http.Request, page *Page, err error) {
+	slog.ErrorContext(
+		r.Context(),
+		"inertia: ssr failed, falling back to client-side rendering",
+		slog.String("component", page.Component),
+		slog.String("url", page.URL),
+		slog.Any("error", err),
+	)
+}
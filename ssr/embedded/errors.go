@@ -0,0 +1,14 @@
+package embedded
+
+import "errors"
+
+var (
+	// ErrRenderFunctionNotFound error.
+	ErrRenderFunctionNotFound = errors.New("embedded: render function not found in the ssr bundle")
+
+	// ErrRenderPending error.
+	ErrRenderPending = errors.New("embedded: render promise did not settle")
+
+	// ErrRenderRejected error.
+	ErrRenderRejected = errors.New("embedded: render promise was rejected")
+)
@@ -0,0 +1,19 @@
+module github.com/petaki/inertia-go/ssr/embedded
+
+go 1.26
+
+require (
+	github.com/dop251/goja v0.0.0-20260106131823-651366fbe6e3
+	github.com/petaki/inertia-go v0.0.0-20261018025809-7dfdf81b7b16
+)
+
+require (
+	github.com/dlclark/regexp2 v1.11.4 // indirect
+	github.com/go-sourcemap/sourcemap v2.1.3+incompatible // indirect
+	github.com/google/pprof v0.0.0-20260115054156-294ebfa9ad83 // indirect
+	golang.org/x/text v0.40.0 // indirect
+)
+
+// Builds against the core module of this checkout. Go ignores replace
+// directives of dependencies, so consumers use the required version above.
+replace github.com/petaki/inertia-go => ../..
@@ -0,0 +1,262 @@
+// Package embedded renders Inertia pages with the built SSR bundle in an
+// embedded pure-Go JavaScript interpreter, so simple apps can server-side
+// render without a Node sidecar.
+//
+// The bundle must expose its render function on the global object, for
+// example globalThis.render = page => createInertiaApp({ page, ... }).
+package embedded
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/petaki/inertia-go"
+)
+
+// Config type.
+type Config struct {
+	Name     string
+	Function string
+	PoolSize int
+	Timeout  time.Duration
+	Logger   *slog.Logger
+}
+
+// Renderer type.
+type Renderer struct {
+	config  Config
+	program *goja.Program
+	slots   chan struct{}
+	idle    chan *vm
+}
+
+type vm struct {
+	runtime   *goja.Runtime
+	render    goja.Callable
+	parse     goja.Callable
+	stringify goja.Callable
+}
+
+// New function.
+func New(source string, config Config) (*Renderer, error) {
+	if config.Name == "" {
+		config.Name = "ssr.js"
+	}
+
+	if config.Function == "" {
+		config.Function = "render"
+	}
+
+	if config.PoolSize <= 0 {
+		config.PoolSize = runtime.GOMAXPROCS(0)
+	}
+
+	if config.Logger == nil {
+		config.Logger = slog.Default()
+	}
+
+	program, err := goja.Compile(config.Name, source, false)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Renderer{
+		config:  config,
+		program: program,
+		slots:   make(chan struct{}, config.PoolSize),
+		idle:    make(chan *vm, config.PoolSize),
+	}
+
+	v, err := r.newVM()
+	if err != nil {
+		return nil, err
+	}
+
+	r.idle <- v
+
+	return r, nil
+}
+
+// Render function.
+func (r *Renderer) Render(ctx context.Context, page *inertia.Page) (*inertia.Ssr, error) {
+	if r.config.Timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, r.config.Timeout)
+		defer cancel()
+	}
+
+	v, err := r.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stop := context.AfterFunc(ctx, func() {
+		v.runtime.Interrupt(ctx.Err())
+	})
+
+	ssr, err := v.call(page)
+
+	if !stop() {
+		r.release(nil)
+
+		return nil, ctx.Err()
+	}
+
+	if err != nil {
+		r.release(nil)
+
+		return nil, err
+	}
+
+	r.release(v)
+
+	return ssr, nil
+}
+
+func (r *Renderer) acquire(ctx context.Context) (*vm, error) {
+	select {
+	case r.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	select {
+	case v := <-r.idle:
+		return v, nil
+	default:
+	}
+
+	v, err := r.newVM()
+	if err != nil {
+		<-r.slots
+
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func (r *Renderer) release(v *vm) {
+	if v != nil {
+		r.idle <- v
+	}
+
+	<-r.slots
+}
+
+func (r *Renderer) newVM() (*vm, error) {
+	rt := goja.New()
+
+	err := rt.Set("console", r.newConsole(rt))
+	if err != nil {
+		return nil, err
+	}
+
+	err = rt.Set("process", map[string]any{
+		"env": map[string]any{
+			"NODE_ENV": "production",
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = rt.RunProgram(r.program)
+	if err != nil {
+		return nil, err
+	}
+
+	render, ok := goja.AssertFunction(rt.Get(r.config.Function))
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrRenderFunctionNotFound, r.config.Function)
+	}
+
+	jsonObject := rt.Get("JSON").ToObject(rt)
+
+	parse, _ := goja.AssertFunction(jsonObject.Get("parse"))
+	stringify, _ := goja.AssertFunction(jsonObject.Get("stringify"))
+
+	return &vm{
+		runtime:   rt,
+		render:    render,
+		parse:     parse,
+		stringify: stringify,
+	}, nil
+}
+
+func (r *Renderer) newConsole(rt *goja.Runtime) *goja.Object {
+	console := rt.NewObject()
+
+	levels := map[string]slog.Level{
+		"debug": slog.LevelDebug,
+		"log":   slog.LevelInfo,
+		"info":  slog.LevelInfo,
+		"warn":  slog.LevelWarn,
+		"error": slog.LevelError,
+	}
+
+	for name, level := range levels {
+		console.Set(name, func(call goja.FunctionCall) goja.Value {
+			args := make([]string, 0, len(call.Arguments))
+
+			for _, arg := range call.Arguments {
+				args = append(args, arg.String())
+			}
+
+			r.config.Logger.Log(context.Background(), level, strings.Join(args, " "), slog.String("stream", "console"))
+
+			return goja.Undefined()
+		})
+	}
+
+	return console
+}
+
+func (v *vm) call(page *inertia.Page) (*inertia.Ssr, error) {
+	data, err := json.Marshal(page)
+	if err != nil {
+		return nil, err
+	}
+
+	arg, err := v.parse(goja.Undefined(), v.runtime.ToValue(string(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := v.render(goja.Undefined(), arg)
+	if err != nil {
+		return nil, err
+	}
+
+	if promise, ok := result.Export().(*goja.Promise); ok {
+		switch promise.State() {
+		case goja.PromiseStateFulfilled:
+			result = promise.Result()
+		case goja.PromiseStateRejected:
+			return nil, fmt.Errorf("%w: %s", ErrRenderRejected, promise.Result())
+		default:
+			return nil, ErrRenderPending
+		}
+	}
+
+	out, err := v.stringify(goja.Undefined(), result)
+	if err != nil {
+		return nil, err
+	}
+
+	var ssr inertia.Ssr
+
+	err = json.Unmarshal([]byte(out.String()), &ssr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ssr, nil
+}
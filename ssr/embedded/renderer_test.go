@@ -0,0 +1,167 @@
+package embedded
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/petaki/inertia-go"
+)
+
+const testBundle = `
+globalThis.render = function (page) {
+	return {
+		head: ["<title>" + page.props.title + "</title>"],
+		body: '<div id="app" data-component="' + page.component + '"></div>',
+	};
+};
+
+globalThis.renderAsync = async function (page) {
+	const body = await Promise.resolve(page.component);
+
+	return { head: [], body: body };
+};
+
+globalThis.renderLoop = function () {
+	for (;;) {}
+};
+
+globalThis.renderThrow = function () {
+	throw new Error("broken");
+};
+`
+
+func newTestPage() *inertia.Page {
+	return &inertia.Page{
+		Component: "test/component",
+		Props:     map[string]any{"title": "Test"},
+		URL:       "/",
+		Version:   "1",
+	}
+}
+
+func TestRender(t *testing.T) {
+	r, err := New(testBundle, Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ssr, err := r.Render(context.Background(), newTestPage())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ssr.Head) != 1 || ssr.Head[0] != "<title>Test</title>" {
+		t.Errorf("unexpected head: %v", ssr.Head)
+	}
+
+	if ssr.Body != `<div id="app" data-component="test/component"></div>` {
+		t.Errorf("unexpected body: %s", ssr.Body)
+	}
+}
+
+func TestRenderPromise(t *testing.T) {
+	r, err := New(testBundle, Config{Function: "renderAsync"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ssr, err := r.Render(context.Background(), newTestPage())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ssr.Body != "test/component" {
+		t.Errorf("expected: test/component, got: %s", ssr.Body)
+	}
+}
+
+func TestRenderTimeout(t *testing.T) {
+	r, err := New(testBundle, Config{Function: "renderLoop", PoolSize: 1, Timeout: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = r.Render(context.Background(), newTestPage())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected: %v, got: %v", context.DeadlineExceeded, err)
+	}
+
+	if len(r.idle) != 0 || len(r.slots) != 0 {
+		t.Error("expected the interrupted vm to be discarded")
+	}
+}
+
+func TestRenderError(t *testing.T) {
+	r, err := New(testBundle, Config{Function: "renderThrow"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = r.Render(context.Background(), newTestPage())
+	if err == nil {
+		t.Fatal("expected an error, got: nil")
+	}
+}
+
+func TestRenderFunctionNotFound(t *testing.T) {
+	_, err := New(testBundle, Config{Function: "missing"})
+	if !errors.Is(err, ErrRenderFunctionNotFound) {
+		t.Errorf("expected: %v, got: %v", ErrRenderFunctionNotFound, err)
+	}
+}
+
+func TestRenderPool(t *testing.T) {
+	r, err := New(testBundle, Config{PoolSize: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+
+	for range 8 {
+		wg.Go(func() {
+			_, err := r.Render(context.Background(), newTestPage())
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	if len(r.idle) > 2 {
+		t.Errorf("expected at most 2 idle vms, got: %d", len(r.idle))
+	}
+}
+
+func TestRendererWithInertia(t *testing.T) {
+	r, err := New(testBundle, Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	templateFS := fstest.MapFS{
+		"app.gohtml": {Data: []byte(`{{ if .ssr }}{{ raw .ssr.Body }}{{ end }}`)},
+	}
+
+	i := inertia.New("http://inertia-go.test", "app.gohtml", "", templateFS)
+	i.EnableSsrWithRenderer(r)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+
+	err = i.Render(w, req, "test/component", map[string]any{"title": "Test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if w.Body.String() != `<div id="app" data-component="test/component"></div>` {
+		t.Errorf("unexpected body: %s", w.Body.String())
+	}
+}
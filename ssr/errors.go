@@ -0,0 +1,11 @@
+package ssr
+
+import "errors"
+
+var (
+	// ErrAlreadyStarted error.
+	ErrAlreadyStarted = errors.New("ssr: supervisor already started")
+
+	// ErrProcessExited error.
+	ErrProcessExited = errors.New("ssr: process exited before it became ready")
+)
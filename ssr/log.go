@@ -0,0 +1,63 @@
+package ssr
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"sync"
+)
+
+type logWriter struct {
+	mu     sync.Mutex
+	logger *slog.Logger
+	level  slog.Level
+	stream string
+	buf    []byte
+}
+
+func newLogWriter(logger *slog.Logger, level slog.Level, stream string) *logWriter {
+	return &logWriter{
+		logger: logger,
+		level:  level,
+		stream: stream,
+	}
+}
+
+// Write function.
+func (w *logWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+
+	for {
+		n := bytes.IndexByte(w.buf, '\n')
+		if n < 0 {
+			break
+		}
+
+		w.log(w.buf[:n])
+		w.buf = w.buf[n+1:]
+	}
+
+	return len(p), nil
+}
+
+func (w *logWriter) flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.buf) > 0 {
+		w.log(w.buf)
+		w.buf = nil
+	}
+}
+
+func (w *logWriter) log(line []byte) {
+	line = bytes.TrimRight(line, "\r")
+	if len(line) == 0 {
+		return
+	}
+
+	w.logger.Log(context.Background(), w.level, string(line), slog.String("stream", w.stream))
+}
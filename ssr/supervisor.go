@@ -0,0 +1,326 @@
+// Package ssr runs and supervises the Node server that renders Inertia
+// pages on the server side.
+package ssr
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Config type.
+type Config struct {
+	Command         string
+	Args            []string
+	Dir             string
+	Env             []string
+	URL             string
+	Logger          *slog.Logger
+	ReadyTimeout    time.Duration
+	PollInterval    time.Duration
+	MinBackoff      time.Duration
+	MaxBackoff      time.Duration
+	ShutdownTimeout time.Duration
+}
+
+// Supervisor type.
+type Supervisor struct {
+	mu      sync.Mutex
+	config  Config
+	client  *http.Client
+	process *process
+	running bool
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+type process struct {
+	cmd     *exec.Cmd
+	started time.Time
+	exited  chan struct{}
+	err     error
+}
+
+// New function.
+func New(config Config) *Supervisor {
+	if config.Command == "" {
+		config.Command = "node"
+	}
+
+	if config.Args == nil {
+		config.Args = []string{"bootstrap/ssr/ssr.mjs"}
+	}
+
+	if config.URL == "" {
+		config.URL = "http://127.0.0.1:13714"
+	}
+
+	config.URL = strings.TrimRight(config.URL, "/")
+
+	if config.Logger == nil {
+		config.Logger = slog.Default()
+	}
+
+	if config.ReadyTimeout <= 0 {
+		config.ReadyTimeout = 10 * time.Second
+	}
+
+	if config.PollInterval <= 0 {
+		config.PollInterval = 100 * time.Millisecond
+	}
+
+	if config.MinBackoff <= 0 {
+		config.MinBackoff = 500 * time.Millisecond
+	}
+
+	if config.MaxBackoff < config.MinBackoff {
+		config.MaxBackoff = max(30*time.Second, config.MinBackoff)
+	}
+
+	if config.ShutdownTimeout <= 0 {
+		config.ShutdownTimeout = 5 * time.Second
+	}
+
+	return &Supervisor{
+		config: config,
+		client: &http.Client{Timeout: config.PollInterval * 10},
+	}
+}
+
+// RenderURL function.
+func (s *Supervisor) RenderURL() string {
+	return s.config.URL + "/render"
+}
+
+// Healthy function.
+func (s *Supervisor) Healthy(ctx context.Context) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.config.URL+"/health", nil)
+	if err != nil {
+		return false
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return false
+	}
+
+	resp.Body.Close()
+
+	return resp.StatusCode == http.StatusOK
+}
+
+// Start function.
+func (s *Supervisor) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		return ErrAlreadyStarted
+	}
+
+	p, err := s.spawn()
+	if err != nil {
+		return err
+	}
+
+	err = s.waitReady(ctx, p)
+	if err != nil {
+		s.kill(p)
+
+		return err
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+
+	s.process = p
+	s.running = true
+	s.cancel = cancel
+	s.done = make(chan struct{})
+
+	go s.supervise(runCtx, p)
+
+	return nil
+}
+
+// Stop function.
+func (s *Supervisor) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.running {
+		return nil
+	}
+
+	s.cancel()
+
+	// Release the lock while the supervise loop winds down, it needs it to
+	// publish a restarted process.
+	s.mu.Unlock()
+	<-s.done
+	s.mu.Lock()
+
+	s.running = false
+
+	return s.shutdown(ctx, s.process)
+}
+
+func (s *Supervisor) spawn() (*process, error) {
+	cmd := exec.Command(s.config.Command, s.config.Args...)
+	cmd.Dir = s.config.Dir
+	cmd.Env = append(os.Environ(), s.config.Env...)
+
+	stdout := newLogWriter(s.config.Logger, slog.LevelInfo, "stdout")
+	stderr := newLogWriter(s.config.Logger, slog.LevelError, "stderr")
+
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Start()
+	if err != nil {
+		return nil, err
+	}
+
+	p := &process{
+		cmd:     cmd,
+		started: time.Now(),
+		exited:  make(chan struct{}),
+	}
+
+	go func() {
+		p.err = cmd.Wait()
+
+		stdout.flush()
+		stderr.flush()
+
+		close(p.exited)
+	}()
+
+	s.config.Logger.Info("ssr: process started", slog.Int("pid", cmd.Process.Pid))
+
+	return p, nil
+}
+
+func (s *Supervisor) waitReady(ctx context.Context, p *process) error {
+	ctx, cancel := context.WithTimeout(ctx, s.config.ReadyTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(s.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if s.Healthy(ctx) {
+			return nil
+		}
+
+		select {
+		case <-p.exited:
+			return fmt.Errorf("%w: %v", ErrProcessExited, p.err)
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Supervisor) supervise(ctx context.Context, p *process) {
+	defer close(s.done)
+
+	backoff := s.config.MinBackoff
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.exited:
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		s.config.Logger.Error("ssr: process exited", slog.Any("error", p.err))
+
+		if time.Since(p.started) > s.config.MaxBackoff {
+			backoff = s.config.MinBackoff
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, s.config.MaxBackoff)
+
+		next, err := s.spawn()
+		if err != nil {
+			s.config.Logger.Error("ssr: could not restart process", slog.Any("error", err))
+
+			next = &process{started: time.Now(), exited: make(chan struct{}), err: err}
+			close(next.exited)
+		}
+
+		s.mu.Lock()
+		s.process = next
+		s.mu.Unlock()
+
+		p = next
+
+		if next.cmd == nil {
+			continue
+		}
+
+		err = s.waitReady(ctx, next)
+		if err != nil && ctx.Err() == nil {
+			s.config.Logger.Error("ssr: process did not become ready", slog.Any("error", err))
+			s.kill(next)
+		}
+	}
+}
+
+func (s *Supervisor) shutdown(ctx context.Context, p *process) error {
+	if p == nil || p.cmd == nil {
+		return nil
+	}
+
+	select {
+	case <-p.exited:
+		return nil
+	default:
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.config.URL+"/shutdown", nil)
+	if err == nil {
+		resp, err := s.client.Do(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+	}
+
+	timer := time.NewTimer(s.config.ShutdownTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-p.exited:
+		return nil
+	case <-timer.C:
+		s.kill(p)
+
+		return nil
+	case <-ctx.Done():
+		s.kill(p)
+
+		return ctx.Err()
+	}
+}
+
+func (s *Supervisor) kill(p *process) {
+	_ = p.cmd.Process.Kill()
+	<-p.exited
+}
@@ -0,0 +1,220 @@
+package ssr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	switch os.Getenv("INERTIA_SSR_HELPER") {
+	case "serve":
+		runHelperServer(os.Getenv("INERTIA_SSR_ADDR"))
+	case "exit":
+		fmt.Fprintln(os.Stderr, "bundle not found")
+		os.Exit(1)
+	default:
+		os.Exit(m.Run())
+	}
+}
+
+func runHelperServer(addr string) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"OK"}`))
+	})
+
+	mux.HandleFunc("/render", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"head":[],"body":"<div id=\"app\"></div>"}`))
+	})
+
+	mux.HandleFunc("/shutdown", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Println("shutting down")
+		os.Exit(0)
+	})
+
+	mux.HandleFunc("/crash", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(os.Stderr, "crashed")
+		os.Exit(1)
+	})
+
+	fmt.Println("listening")
+
+	err := http.ListenAndServe(addr, mux)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
+
+func newTestSupervisor(t *testing.T, mode string) (*Supervisor, *syncBuffer) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr := ln.Addr().String()
+	ln.Close()
+
+	logs := &syncBuffer{}
+
+	s := New(Config{
+		Command:      os.Args[0],
+		Args:         []string{},
+		Env:          []string{"INERTIA_SSR_HELPER=" + mode, "INERTIA_SSR_ADDR=" + addr},
+		URL:          "http://" + addr,
+		Logger:       slog.New(slog.NewTextHandler(logs, nil)),
+		ReadyTimeout: 5 * time.Second,
+		PollInterval: 10 * time.Millisecond,
+		MinBackoff:   10 * time.Millisecond,
+		MaxBackoff:   100 * time.Millisecond,
+	})
+
+	t.Cleanup(func() {
+		s.Stop(context.Background())
+	})
+
+	return s, logs
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met before the deadline")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestNew(t *testing.T) {
+	s := New(Config{})
+
+	if s.config.Command != "node" {
+		t.Errorf("expected: node, got: %s", s.config.Command)
+	}
+
+	if len(s.config.Args) != 1 || s.config.Args[0] != "bootstrap/ssr/ssr.mjs" {
+		t.Errorf("expected: bootstrap/ssr/ssr.mjs, got: %v", s.config.Args)
+	}
+
+	if s.RenderURL() != "http://127.0.0.1:13714/render" {
+		t.Errorf("expected: http://127.0.0.1:13714/render, got: %s", s.RenderURL())
+	}
+}
+
+func TestSupervisorStartStop(t *testing.T) {
+	s, logs := newTestSupervisor(t, "serve")
+
+	err := s.Start(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !s.Healthy(context.Background()) {
+		t.Error("expected the process to be healthy")
+	}
+
+	err = s.Start(context.Background())
+	if !errors.Is(err, ErrAlreadyStarted) {
+		t.Errorf("expected: %v, got: %v", ErrAlreadyStarted, err)
+	}
+
+	p := s.process
+
+	err = s.Stop(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if code := p.cmd.ProcessState.ExitCode(); code != 0 {
+		t.Errorf("expected graceful exit code 0, got: %d", code)
+	}
+
+	if s.Healthy(context.Background()) {
+		t.Error("expected the process to be stopped")
+	}
+
+	for _, line := range []string{"listening", "shutting down"} {
+		if !strings.Contains(logs.String(), line) {
+			t.Errorf("expected %q in logs, got: %s", line, logs.String())
+		}
+	}
+}
+
+func TestSupervisorRestart(t *testing.T) {
+	s, logs := newTestSupervisor(t, "serve")
+
+	err := s.Start(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.mu.Lock()
+	pid := s.process.cmd.Process.Pid
+	s.mu.Unlock()
+
+	resp, err := http.Get(s.config.URL + "/crash")
+	if err == nil {
+		resp.Body.Close()
+	}
+
+	waitFor(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		return s.process.cmd != nil && s.process.cmd.Process.Pid != pid && s.Healthy(context.Background())
+	})
+
+	if !strings.Contains(logs.String(), "crashed") {
+		t.Errorf("expected stderr in logs, got: %s", logs.String())
+	}
+}
+
+func TestSupervisorStartProcessExited(t *testing.T) {
+	s, logs := newTestSupervisor(t, "exit")
+
+	err := s.Start(context.Background())
+	if !errors.Is(err, ErrProcessExited) {
+		t.Errorf("expected: %v, got: %v", ErrProcessExited, err)
+	}
+
+	if !strings.Contains(logs.String(), "bundle not found") {
+		t.Errorf("expected stderr in logs, got: %s", logs.String())
+	}
+}
@@ -0,0 +1,436 @@
+package inertia
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const ssrFallbackTestTemplate = `{{ if .ssr }}{{ raw .ssr.Body }}{{ else }}<div id="app"></div>{{ end }}`
+
+func TestRenderWithSsr(t *testing.T) {
+	ts := newSsrTestServer(t, func() {})
+
+	i := newSsrTestInertia(ts.URL, ssrFallbackTestTemplate)
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+
+	err := i.Render(w, r, "test/component", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if w.Body.String() != `<div id="app"></div>` {
+		t.Errorf("unexpected body: %s", w.Body.String())
+	}
+}
+
+func TestRenderWithSsrError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	i := newSsrTestInertia(ts.URL, ssrFallbackTestTemplate)
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+
+	err := i.Render(w, r, "test/component", nil)
+	if !errors.Is(err, ErrBadSsrStatusCode) {
+		t.Errorf("expected: %v, got: %v", ErrBadSsrStatusCode, err)
+	}
+
+	if w.Body.Len() != 0 {
+		t.Errorf("expected empty body, got: %s", w.Body.String())
+	}
+}
+
+func TestRenderWithSsrFallback(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{
+			name: "bad status code",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+		},
+		{
+			name: "bad json",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("{"))
+			},
+		},
+		{
+			name: "connection refused",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(tt.handler)
+
+			if tt.handler == nil {
+				ts.Close()
+			} else {
+				defer ts.Close()
+			}
+
+			i := newSsrTestInertia(ts.URL, ssrFallbackTestTemplate)
+
+			var reported error
+			var component string
+
+			i.EnableSsrFallback(func(r *http.Request, page *Page, err error) {
+				reported = err
+				component = page.Component
+			})
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			w := httptest.NewRecorder()
+
+			err := i.Render(w, r, "test/component", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if reported == nil {
+				t.Error("expected the ssr error to be reported")
+			}
+
+			if component != "test/component" {
+				t.Errorf("expected: test/component, got: %s", component)
+			}
+
+			if !strings.Contains(w.Body.String(), `<div id="app"></div>`) {
+				t.Errorf("unexpected body: %s", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestDisableSsrFallback(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	i := newSsrTestInertia(ts.URL, ssrFallbackTestTemplate)
+	i.EnableSsrFallback()
+
+	if i.config.Load().ssrFallback == nil {
+		t.Fatal("expected: default error handler, got: nil")
+	}
+
+	i.DisableSsrFallback()
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+
+	err := i.Render(w, r, "test/component", nil)
+	if !errors.Is(err, ErrBadSsrStatusCode) {
+		t.Errorf("expected: %v, got: %v", ErrBadSsrStatusCode, err)
+	}
+}
+
+func TestRenderWithSsrTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	ts := newSsrTestServer(t, func() {
+		<-release
+	})
+
+	i := newSsrTestInertia(ts.URL, ssrFallbackTestTemplate)
+	i.SetSsrOptions(SsrOptions{Timeout: 20 * time.Millisecond})
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+
+	err := i.Render(w, r, "test/component", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected: %v, got: %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestRenderWithSsrRetries(t *testing.T) {
+	var calls atomic.Int32
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+
+			return
+		}
+
+		w.Write([]byte(`{"head":[],"body":"<div id=\"ssr\"></div>"}`))
+	}))
+	defer ts.Close()
+
+	i := newSsrTestInertia(ts.URL, ssrFallbackTestTemplate)
+	i.SetSsrOptions(SsrOptions{Retries: 2, RetryBackoff: time.Millisecond})
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+
+	err := i.Render(w, r, "test/component", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 calls, got: %d", calls.Load())
+	}
+
+	if w.Body.String() != `<div id="ssr"></div>` {
+		t.Errorf("unexpected body: %s", w.Body.String())
+	}
+}
+
+func TestRenderWithSsrBreaker(t *testing.T) {
+	var calls atomic.Int32
+	var healthy atomic.Bool
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		w.Write([]byte(`{"head":[],"body":"<div id=\"ssr\"></div>"}`))
+	}))
+	defer ts.Close()
+
+	i := newSsrTestInertia(ts.URL, ssrFallbackTestTemplate)
+	i.SetSsrOptions(SsrOptions{BreakerThreshold: 2, BreakerCooldown: 50 * time.Millisecond})
+
+	render := func() (string, error) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := httptest.NewRecorder()
+
+		err := i.Render(w, r, "test/component", nil)
+
+		return w.Body.String(), err
+	}
+
+	if i.SsrBreakerState() != BreakerClosed {
+		t.Errorf("expected: %s, got: %s", BreakerClosed, i.SsrBreakerState())
+	}
+
+	for range 2 {
+		_, err := render()
+		if !errors.Is(err, ErrBadSsrStatusCode) {
+			t.Errorf("expected: %v, got: %v", ErrBadSsrStatusCode, err)
+		}
+	}
+
+	if i.SsrBreakerState() != BreakerOpen {
+		t.Errorf("expected: %s, got: %s", BreakerOpen, i.SsrBreakerState())
+	}
+
+	body, err := render()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if body != `<div id="app"></div>` {
+		t.Errorf("unexpected body: %s", body)
+	}
+
+	if calls.Load() != 2 {
+		t.Errorf("expected 2 calls, got: %d", calls.Load())
+	}
+
+	time.Sleep(60 * time.Millisecond)
+
+	if i.SsrBreakerState() != BreakerHalfOpen {
+		t.Errorf("expected: %s, got: %s", BreakerHalfOpen, i.SsrBreakerState())
+	}
+
+	healthy.Store(true)
+
+	body, err = render()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if body != `<div id="ssr"></div>` {
+		t.Errorf("unexpected body: %s", body)
+	}
+
+	if i.SsrBreakerState() != BreakerClosed {
+		t.Errorf("expected: %s, got: %s", BreakerClosed, i.SsrBreakerState())
+	}
+}
+
+func TestRenderWithoutSsr(t *testing.T) {
+	var calls atomic.Int32
+
+	ts := newSsrTestServer(t, func() {
+		calls.Add(1)
+	})
+
+	i := newSsrTestInertia(ts.URL, ssrFallbackTestTemplate)
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r = r.WithContext(i.WithoutSsr(r.Context()))
+	w := httptest.NewRecorder()
+
+	err := i.Render(w, r, "test/component", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if calls.Load() != 0 {
+		t.Errorf("expected no ssr calls, got: %d", calls.Load())
+	}
+
+	if !i.IsSsrEnabled() {
+		t.Error("expected ssr to stay enabled")
+	}
+}
+
+func TestExcludeSsrComponents(t *testing.T) {
+	var calls atomic.Int32
+
+	ts := newSsrTestServer(t, func() {
+		calls.Add(1)
+	})
+
+	i := newSsrTestInertia(ts.URL, ssrFallbackTestTemplate)
+
+	err := i.ExcludeSsrComponents("Admin/*")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = i.ExcludeSsrComponents("Reports/*/Chart")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, component := range []string{"Admin/Dashboard", "Reports/Sales/Chart", "Users/Index", "Admin/Users/Index", "Reports/Sales/Table", "AdminPanel"} {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := httptest.NewRecorder()
+
+		err = i.Render(w, r, component, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 ssr calls, got: %d", calls.Load())
+	}
+
+	err = i.ExcludeSsrComponents("Admin/[")
+	if !errors.Is(err, path.ErrBadPattern) {
+		t.Errorf("expected: %v, got: %v", path.ErrBadPattern, err)
+	}
+}
+
+func TestEnableSsrWithRenderer(t *testing.T) {
+	var component string
+
+	i := newSsrTestInertia("", ssrFallbackTestTemplate)
+	i.EnableSsrWithRenderer(SsrRendererFunc(func(ctx context.Context, page *Page) (*Ssr, error) {
+		component = page.Component
+
+		return &Ssr{Body: `<div id="fake"></div>`}, nil
+	}))
+
+	body := renderSsrTestPage(t, i)
+	if body != `<div id="fake"></div>` {
+		t.Errorf("unexpected body: %s", body)
+	}
+
+	if component != "test/component" {
+		t.Errorf("expected: test/component, got: %s", component)
+	}
+
+	if i.SsrBackends() != nil {
+		t.Error("expected no backends for a custom renderer")
+	}
+
+	i.EnableSsrWithRenderer(nil)
+
+	if i.IsSsrEnabled() {
+		t.Error("expected: false, got: true")
+	}
+}
+
+func TestEnableSsrWithRendererTimeout(t *testing.T) {
+	i := newSsrTestInertia("", ssrFallbackTestTemplate)
+	i.SetSsrOptions(SsrOptions{Timeout: 10 * time.Millisecond})
+	i.EnableSsrWithRenderer(SsrRendererFunc(func(ctx context.Context, page *Page) (*Ssr, error) {
+		<-ctx.Done()
+
+		return nil, ctx.Err()
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+
+	err := i.Render(w, r, "test/component", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected: %v, got: %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestSsrRendererDecorator(t *testing.T) {
+	var calls atomic.Int32
+
+	ts := newSsrTestServer(t, func() {})
+
+	i := newSsrTestInertia(ts.URL, ssrTestTemplate)
+
+	if _, ok := i.SsrRenderer().(*HTTPSsrRenderer); !ok {
+		t.Fatalf("expected: *HTTPSsrRenderer, got: %T", i.SsrRenderer())
+	}
+
+	next := i.SsrRenderer()
+
+	i.EnableSsrWithRenderer(SsrRendererFunc(func(ctx context.Context, page *Page) (*Ssr, error) {
+		calls.Add(1)
+
+		return next.Render(ctx, page)
+	}))
+
+	body := renderSsrTestPage(t, i)
+	if body != `<div id="app"></div>` {
+		t.Errorf("unexpected body: %s", body)
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("expected: 1, got: %d", calls.Load())
+	}
+}
+
+func TestNewHTTPSsrRenderer(t *testing.T) {
+	ts := newSsrTestServer(t, func() {})
+
+	r := NewHTTPSsrRenderer(RoundRobin, []string{ts.URL})
+
+	ssr, err := r.Render(context.Background(), &Page{Component: "test/component"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ssr.Body != `<div id="app"></div>` {
+		t.Errorf("unexpected body: %s", ssr.Body)
+	}
+
+	if NewHTTPSsrRenderer(RoundRobin, nil) != nil {
+		t.Error("expected: nil, got: *HTTPSsrRenderer")
+	}
+}
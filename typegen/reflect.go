@@ -0,0 +1,169 @@
+package typegen
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+)
+
+var (
+	timeType       = reflect.TypeFor[time.Time]()
+	rawMessageType = reflect.TypeFor[json.RawMessage]()
+	marshalerType  = reflect.TypeFor[json.Marshaler]()
+)
+
+func (g *Generator) reflectProps(component string, t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return g.reflectType(t)
+	}
+
+	name := t.Name()
+	if name == "" {
+		name = componentName(component) + "Props"
+	}
+
+	return g.declare(t, typeName(name), func() []field {
+		return g.reflectPropFields(t)
+	})
+}
+
+func (g *Generator) reflectPropFields(t reflect.Type) []field {
+	var fields []field
+
+	for idx := range t.NumField() {
+		f := t.Field(idx)
+		inertiaTag, hasTag := f.Tag.Lookup("inertia")
+
+		if f.Anonymous && !hasTag && inertiaTag != "-" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+
+			if ft.Kind() == reflect.Struct {
+				fields = append(fields, g.reflectPropFields(ft)...)
+
+				continue
+			}
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+
+		opts := parsePropTag(f.Name, inertiaTag, f.Tag.Get("json"))
+		if opts.skip {
+			continue
+		}
+
+		ft := f.Type
+		if ft.Kind() == reflect.Func {
+			if ft.NumOut() == 0 {
+				continue
+			}
+
+			ft = ft.Out(0)
+		}
+
+		fields = append(fields, field{
+			name:     propertyName(opts.key),
+			typ:      g.reflectType(ft),
+			optional: opts.optional,
+		})
+	}
+
+	return fields
+}
+
+func (g *Generator) reflectType(t reflect.Type) string {
+	if t == nil {
+		return "unknown"
+	}
+
+	switch t {
+	case timeType:
+		return "string"
+	case rawMessageType:
+		return "unknown"
+	}
+
+	if t.Kind() == reflect.Pointer {
+		return g.reflectType(t.Elem()) + " | null"
+	}
+
+	if t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType) {
+		return "unknown"
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			return "string"
+		}
+
+		return arrayOf(g.reflectType(t.Elem()))
+	case reflect.Map:
+		return "Record<string, " + g.reflectType(t.Elem()) + ">"
+	case reflect.Struct:
+		if t.Name() == "" {
+			return inlineInterface(g.reflectFields(t))
+		}
+
+		return g.declare(t, typeName(t.Name()), func() []field {
+			return g.reflectFields(t)
+		})
+	}
+
+	return "unknown"
+}
+
+func (g *Generator) reflectFields(t reflect.Type) []field {
+	var fields []field
+
+	for idx := range t.NumField() {
+		f := t.Field(idx)
+		jsonTag, hasTag := f.Tag.Lookup("json")
+
+		if f.Anonymous && !hasTag {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+
+			if ft.Kind() == reflect.Struct {
+				fields = append(fields, g.reflectFields(ft)...)
+
+				continue
+			}
+		}
+
+		if !f.IsExported() || f.Type.Kind() == reflect.Func || f.Type.Kind() == reflect.Chan {
+			continue
+		}
+
+		opts := parseJSONTag(f.Name, jsonTag)
+		if opts.skip {
+			continue
+		}
+
+		fields = append(fields, field{
+			name:     propertyName(opts.key),
+			typ:      g.reflectType(f.Type),
+			optional: opts.omitEmpty,
+		})
+	}
+
+	return fields
+}
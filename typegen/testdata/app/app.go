@@ -0,0 +1,49 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/petaki/inertia-go"
+)
+
+type Timestamps struct {
+	CreatedAt time.Time  `json:"createdAt"`
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+}
+
+type User struct {
+	Timestamps
+	ID       int            `json:"id"`
+	Name     string         `json:"name"`
+	Email    *string        `json:"email,omitempty"`
+	Roles    []string       `json:"roles"`
+	Meta     map[string]any `json:"meta"`
+	Manager  *User          `json:"manager"`
+	Password string         `json:"-"`
+	secret   string
+}
+
+type UsersIndexProps struct {
+	Title   string                                `json:"title"`
+	Users   func(context.Context) ([]User, error) `inertia:"users,deferred"`
+	Stats   func() map[string]int                 `inertia:"stats,optional"`
+	Feed    func() []User                         `inertia:"feed,merge,matchOn=id"`
+	Filters struct {
+		Search string `json:"search"`
+	} `json:"filters"`
+	Ignored string `inertia:"-"`
+	Token   string `json:"-"`
+}
+
+type Auth struct {
+	User *User `json:"user"`
+}
+
+func Register(i *inertia.Inertia) {
+	i.Share("appName", "Inertia")
+	i.Share("auth", Auth{})
+
+	inertia.NewComponent[UsersIndexProps](i, "Users/Index")
+	inertia.NewComponent[map[string]any](i, "Home")
+}
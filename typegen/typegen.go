@@ -0,0 +1,312 @@
+// Package typegen generates TypeScript declarations for Inertia page props.
+package typegen
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/petaki/inertia-go"
+)
+
+// Generator type.
+type Generator struct {
+	components map[string]string
+	shared     map[string]string
+	decls      map[string][]field
+	named      map[any]string
+}
+
+type field struct {
+	name     string
+	typ      string
+	optional bool
+}
+
+// New function.
+func New() *Generator {
+	return &Generator{
+		components: make(map[string]string),
+		shared:     make(map[string]string),
+		decls:      make(map[string][]field),
+		named:      make(map[any]string),
+	}
+}
+
+// FromInertia function.
+func FromInertia(i *inertia.Inertia) *Generator {
+	g := New()
+
+	for name, t := range i.Components() {
+		g.AddComponent(name, t)
+	}
+
+	for key, value := range i.SharedProps() {
+		g.AddShared(key, reflect.TypeOf(value))
+	}
+
+	return g
+}
+
+// AddComponent function.
+func (g *Generator) AddComponent(name string, t reflect.Type) {
+	g.components[name] = g.reflectProps(name, t)
+}
+
+// AddShared function.
+func (g *Generator) AddShared(key string, t reflect.Type) {
+	g.shared[key] = g.reflectType(t)
+}
+
+// Write function.
+func (g *Generator) Write(w io.Writer) error {
+	var b strings.Builder
+
+	b.WriteString("// Code generated by inertia-typegen. DO NOT EDIT.\n\n")
+	b.WriteString("export type Errors = Record<string, string | Record<string, string>>;\n\n")
+	b.WriteString("export type Flash = Record<string, unknown>;\n\n")
+
+	for _, name := range slices.Sorted(maps.Keys(g.decls)) {
+		writeInterface(&b, name, "", g.decls[name])
+	}
+
+	var sharedFields []field
+
+	for _, key := range slices.Sorted(maps.Keys(g.shared)) {
+		sharedFields = append(sharedFields, field{name: key, typ: g.shared[key]})
+	}
+
+	writeInterface(&b, "SharedProps", "", sharedFields)
+
+	var pages []field
+
+	for _, name := range slices.Sorted(maps.Keys(g.components)) {
+		pageName := g.uniqueName(componentName(name) + "Page")
+		props := g.components[name]
+		extends := "SharedProps"
+
+		var fields []field
+
+		if _, ok := g.decls[props]; ok {
+			extends += ", " + props
+		} else {
+			fields = append(fields, field{name: "[key: string]", typ: "unknown"})
+		}
+
+		fields = append(fields, field{name: "errors", typ: "Errors"})
+
+		writeInterface(&b, pageName, extends, fields)
+
+		pages = append(pages, field{name: quote(name), typ: pageName})
+	}
+
+	writeInterface(&b, "Pages", "", pages)
+
+	_, err := io.WriteString(w, strings.TrimSuffix(b.String(), "\n"))
+
+	return err
+}
+
+func (g *Generator) declare(key any, name string, fields func() []field) string {
+	if existing, ok := g.named[key]; ok {
+		return existing
+	}
+
+	name = g.uniqueName(name)
+
+	// Register the name before the fields, so recursive types refer to it.
+	g.named[key] = name
+	g.decls[name] = nil
+	g.decls[name] = fields()
+
+	return name
+}
+
+func (g *Generator) uniqueName(name string) string {
+	base := name
+
+	for n := 2; ; n++ {
+		if _, ok := g.decls[name]; !ok {
+			return name
+		}
+
+		name = fmt.Sprintf("%s%d", base, n)
+	}
+}
+
+func writeInterface(b *strings.Builder, name, extends string, fields []field) {
+	b.WriteString("export interface " + name)
+
+	if extends != "" {
+		b.WriteString(" extends " + extends)
+	}
+
+	if len(fields) == 0 {
+		b.WriteString(" {}\n\n")
+
+		return
+	}
+
+	b.WriteString(" {\n")
+
+	for _, f := range fields {
+		optional := ""
+		if f.optional {
+			optional = "?"
+		}
+
+		b.WriteString("  " + f.name + optional + ": " + f.typ + ";\n")
+	}
+
+	b.WriteString("}\n\n")
+}
+
+func componentName(name string) string {
+	var b strings.Builder
+
+	upper := true
+
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+
+			continue
+		}
+
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+func typeName(name string) string {
+	return componentName(name)
+}
+
+func propertyName(name string) string {
+	for idx, r := range name {
+		if r == '_' || r == '$' || unicode.IsLetter(r) || (idx > 0 && unicode.IsDigit(r)) {
+			continue
+		}
+
+		return quote(name)
+	}
+
+	return name
+}
+
+func quote(name string) string {
+	return fmt.Sprintf("%q", name)
+}
+
+type propOptions struct {
+	key      string
+	skip     bool
+	optional bool
+}
+
+func parsePropTag(fieldName, inertiaTag, jsonTag string) propOptions {
+	if inertiaTag == "-" {
+		return propOptions{skip: true}
+	}
+
+	name, options, _ := strings.Cut(inertiaTag, ",")
+
+	if name == "" && jsonTag == "-" {
+		return propOptions{skip: true}
+	}
+
+	opts := propOptions{
+		key: name,
+	}
+
+	if opts.key == "" {
+		jsonName, _, _ := strings.Cut(jsonTag, ",")
+		if jsonName != "" {
+			opts.key = jsonName
+		} else {
+			opts.key = fieldName
+		}
+	}
+
+	for option := range strings.SplitSeq(options, ",") {
+		option, _, _ = strings.Cut(strings.TrimSpace(option), "=")
+
+		switch option {
+		case "optional", "deferred", "once":
+			opts.optional = true
+		}
+	}
+
+	return opts
+}
+
+type jsonOptions struct {
+	key       string
+	skip      bool
+	omitEmpty bool
+}
+
+func parseJSONTag(fieldName, jsonTag string) jsonOptions {
+	if jsonTag == "-" {
+		return jsonOptions{skip: true}
+	}
+
+	name, options, _ := strings.Cut(jsonTag, ",")
+
+	opts := jsonOptions{
+		key: name,
+	}
+
+	if opts.key == "" {
+		opts.key = fieldName
+	}
+
+	for option := range strings.SplitSeq(options, ",") {
+		if option == "omitempty" || option == "omitzero" {
+			opts.omitEmpty = true
+		}
+	}
+
+	return opts
+}
+
+func arrayOf(typ string) string {
+	if strings.ContainsAny(typ, " |&") {
+		return "(" + typ + ")[]"
+	}
+
+	return typ + "[]"
+}
+
+func inlineInterface(fields []field) string {
+	if len(fields) == 0 {
+		return "Record<string, never>"
+	}
+
+	var b strings.Builder
+
+	b.WriteString("{ ")
+
+	for _, f := range fields {
+		optional := ""
+		if f.optional {
+			optional = "?"
+		}
+
+		b.WriteString(f.name + optional + ": " + f.typ + "; ")
+	}
+
+	b.WriteString("}")
+
+	return b.String()
+}
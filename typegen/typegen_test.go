@@ -0,0 +1,94 @@
+package typegen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/petaki/inertia-go"
+	"github.com/petaki/inertia-go/typegen/testdata/app"
+)
+
+const expectedDeclarations = `// Code generated by inertia-typegen. DO NOT EDIT.
+
+export type Errors = Record<string, string | Record<string, string>>;
+
+export type Flash = Record<string, unknown>;
+
+export interface Auth {
+  user: User | null;
+}
+
+export interface User {
+  createdAt: string;
+  deletedAt?: string | null;
+  id: number;
+  name: string;
+  email?: string | null;
+  roles: string[];
+  meta: Record<string, unknown>;
+  manager: User | null;
+}
+
+export interface UsersIndexProps {
+  title: string;
+  users?: User[];
+  stats?: Record<string, number>;
+  feed: User[];
+  filters: { search: string; };
+}
+
+export interface SharedProps {
+  appName: string;
+  auth: Auth;
+}
+
+export interface HomePage extends SharedProps {
+  [key: string]: unknown;
+  errors: Errors;
+}
+
+export interface UsersIndexPage extends SharedProps, UsersIndexProps {
+  errors: Errors;
+}
+
+export interface Pages {
+  "Home": HomePage;
+  "Users/Index": UsersIndexPage;
+}
+`
+
+func TestFromInertia(t *testing.T) {
+	i := inertia.New("http://inertia-go.test", "", "")
+	app.Register(i)
+
+	var b strings.Builder
+
+	err := FromInertia(i).Write(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.String() != expectedDeclarations {
+		t.Errorf("expected:\n%s\ngot:\n%s", expectedDeclarations, b.String())
+	}
+}
+
+func TestLoadPackage(t *testing.T) {
+	g := New()
+
+	err := g.LoadPackage("testdata/app")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b strings.Builder
+
+	err = g.Write(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.String() != expectedDeclarations {
+		t.Errorf("expected:\n%s\ngot:\n%s", expectedDeclarations, b.String())
+	}
+}
@@ -0,0 +1,326 @@
+package typegen
+
+import (
+	"go/ast"
+	"go/build"
+	"go/constant"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"reflect"
+)
+
+const inertiaPath = "github.com/petaki/inertia-go"
+
+var marshalerInterface = func() *types.Interface {
+	results := types.NewTuple(
+		types.NewVar(token.NoPos, nil, "", types.NewSlice(types.Typ[types.Byte])),
+		types.NewVar(token.NoPos, nil, "", types.Universe.Lookup("error").Type()),
+	)
+
+	method := types.NewFunc(token.NoPos, nil, "MarshalJSON", types.NewSignatureType(nil, nil, nil, nil, results, false))
+
+	return types.NewInterfaceType([]*types.Func{method}, nil).Complete()
+}()
+
+// LoadPackage function.
+func (g *Generator) LoadPackage(dir string) error {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+
+	bp, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return err
+	}
+
+	fset := token.NewFileSet()
+
+	var files []*ast.File
+
+	for _, name := range bp.GoFiles {
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return err
+		}
+
+		files = append(files, file)
+	}
+
+	info := &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Instances:  make(map[*ast.Ident]types.Instance),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+	}
+
+	_, err = conf.Check(bp.ImportPath, fset, files, info)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+
+			g.inspectCall(info, call)
+
+			return true
+		})
+	}
+
+	return nil
+}
+
+func (g *Generator) inspectCall(info *types.Info, call *ast.CallExpr) {
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.IndexExpr:
+		g.inspectNewComponent(info, call, fun.X)
+	case *ast.IndexListExpr:
+		g.inspectNewComponent(info, call, fun.X)
+	case *ast.SelectorExpr:
+		selection, ok := info.Selections[fun]
+		if !ok || !isInertiaObject(selection.Obj(), "Share") || len(call.Args) != 2 {
+			return
+		}
+
+		key, ok := constantString(info, call.Args[0])
+		if !ok {
+			return
+		}
+
+		g.shared[key] = g.typesType(info.TypeOf(call.Args[1]))
+	}
+}
+
+func (g *Generator) inspectNewComponent(info *types.Info, call *ast.CallExpr, x ast.Expr) {
+	var ident *ast.Ident
+
+	switch x := ast.Unparen(x).(type) {
+	case *ast.Ident:
+		ident = x
+	case *ast.SelectorExpr:
+		ident = x.Sel
+	default:
+		return
+	}
+
+	if !isInertiaObject(info.Uses[ident], "NewComponent") || len(call.Args) != 2 {
+		return
+	}
+
+	instance, ok := info.Instances[ident]
+	if !ok || instance.TypeArgs.Len() != 1 {
+		return
+	}
+
+	name, ok := constantString(info, call.Args[1])
+	if !ok {
+		return
+	}
+
+	g.components[name] = g.typesProps(name, instance.TypeArgs.At(0))
+}
+
+func isInertiaObject(obj types.Object, name string) bool {
+	return obj != nil && obj.Pkg() != nil && obj.Pkg().Path() == inertiaPath && obj.Name() == name
+}
+
+func constantString(info *types.Info, expr ast.Expr) (string, bool) {
+	tv, ok := info.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+
+	return constant.StringVal(tv.Value), true
+}
+
+func (g *Generator) typesProps(component string, t types.Type) string {
+	t = types.Unalias(t)
+
+	if pointer, ok := t.(*types.Pointer); ok {
+		t = types.Unalias(pointer.Elem())
+	}
+
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return g.typesType(t)
+	}
+
+	name := componentName(component) + "Props"
+	key := any(t)
+
+	if named, ok := t.(*types.Named); ok {
+		name = named.Obj().Name()
+		key = types.TypeString(named, nil)
+	}
+
+	return g.declare(key, typeName(name), func() []field {
+		return g.typesPropFields(st)
+	})
+}
+
+func (g *Generator) typesPropFields(st *types.Struct) []field {
+	var fields []field
+
+	for idx := range st.NumFields() {
+		f := st.Field(idx)
+		inertiaTag, hasTag := reflect.StructTag(st.Tag(idx)).Lookup("inertia")
+
+		if f.Embedded() && !hasTag {
+			if embedded, ok := embeddedStruct(f.Type()); ok {
+				fields = append(fields, g.typesPropFields(embedded)...)
+
+				continue
+			}
+		}
+
+		if !f.Exported() {
+			continue
+		}
+
+		opts := parsePropTag(f.Name(), inertiaTag, reflect.StructTag(st.Tag(idx)).Get("json"))
+		if opts.skip {
+			continue
+		}
+
+		ft := f.Type()
+		if signature, ok := ft.Underlying().(*types.Signature); ok {
+			if signature.Results().Len() == 0 {
+				continue
+			}
+
+			ft = signature.Results().At(0).Type()
+		}
+
+		fields = append(fields, field{
+			name:     propertyName(opts.key),
+			typ:      g.typesType(ft),
+			optional: opts.optional,
+		})
+	}
+
+	return fields
+}
+
+func (g *Generator) typesType(t types.Type) string {
+	if t == nil {
+		return "unknown"
+	}
+
+	t = types.Unalias(t)
+
+	if named, ok := t.(*types.Named); ok {
+		obj := named.Obj()
+
+		if obj.Pkg() != nil {
+			switch obj.Pkg().Path() + "." + obj.Name() {
+			case "time.Time":
+				return "string"
+			case "encoding/json.RawMessage":
+				return "unknown"
+			}
+		}
+
+		if types.Implements(named, marshalerInterface) || types.Implements(types.NewPointer(named), marshalerInterface) {
+			return "unknown"
+		}
+
+		if st, ok := named.Underlying().(*types.Struct); ok {
+			return g.declare(types.TypeString(named, nil), typeName(obj.Name()), func() []field {
+				return g.typesFields(st)
+			})
+		}
+
+		return g.typesType(named.Underlying())
+	}
+
+	switch u := t.(type) {
+	case *types.Basic:
+		switch {
+		case u.Kind() == types.UntypedNil:
+			return "null"
+		case u.Info()&types.IsBoolean != 0:
+			return "boolean"
+		case u.Info()&types.IsNumeric != 0:
+			return "number"
+		case u.Info()&types.IsString != 0:
+			return "string"
+		}
+	case *types.Pointer:
+		return g.typesType(u.Elem()) + " | null"
+	case *types.Slice:
+		if basic, ok := types.Unalias(u.Elem()).(*types.Basic); ok && basic.Kind() == types.Byte {
+			return "string"
+		}
+
+		return arrayOf(g.typesType(u.Elem()))
+	case *types.Array:
+		return arrayOf(g.typesType(u.Elem()))
+	case *types.Map:
+		return "Record<string, " + g.typesType(u.Elem()) + ">"
+	case *types.Struct:
+		return inlineInterface(g.typesFields(u))
+	}
+
+	return "unknown"
+}
+
+func (g *Generator) typesFields(st *types.Struct) []field {
+	var fields []field
+
+	for idx := range st.NumFields() {
+		f := st.Field(idx)
+		jsonTag, hasTag := reflect.StructTag(st.Tag(idx)).Lookup("json")
+
+		if f.Embedded() && !hasTag {
+			if embedded, ok := embeddedStruct(f.Type()); ok {
+				fields = append(fields, g.typesFields(embedded)...)
+
+				continue
+			}
+		}
+
+		if !f.Exported() {
+			continue
+		}
+
+		switch f.Type().Underlying().(type) {
+		case *types.Signature, *types.Chan:
+			continue
+		}
+
+		opts := parseJSONTag(f.Name(), jsonTag)
+		if opts.skip {
+			continue
+		}
+
+		fields = append(fields, field{
+			name:     propertyName(opts.key),
+			typ:      g.typesType(f.Type()),
+			optional: opts.omitEmpty,
+		})
+	}
+
+	return fields
+}
+
+func embeddedStruct(t types.Type) (*types.Struct, bool) {
+	if pointer, ok := types.Unalias(t).(*types.Pointer); ok {
+		t = pointer.Elem()
+	}
+
+	st, ok := t.Underlying().(*types.Struct)
+
+	return st, ok
+}
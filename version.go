@@ -0,0 +1,189 @@
+package inertia
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// VersionFunc type.
+type VersionFunc func(r *http.Request) string
+
+// versionReloadInterval throttles the stamp check of reloading versions,
+// so a directory is not walked on every request.
+var versionReloadInterval = time.Second
+
+type versionCache struct {
+	mu      sync.Mutex
+	reload  bool
+	stamp   func() (string, error)
+	hash    func() (string, error)
+	current string
+	version string
+	checked time.Time
+}
+
+// ManifestVersion function.
+func ManifestVersion(fsys fs.FS, name string, reload ...bool) (VersionFunc, error) {
+	return newVersionCache(
+		func() (string, error) {
+			return statStamp(fsys, name)
+		},
+		func() (string, error) {
+			return hashFiles(fsys, []string{name})
+		},
+		len(reload) > 0 && reload[0],
+	)
+}
+
+// DirVersion function.
+func DirVersion(fsys fs.FS, dir string, reload ...bool) (VersionFunc, error) {
+	return newVersionCache(
+		func() (string, error) {
+			files, err := walkFiles(fsys, dir)
+			if err != nil {
+				return "", err
+			}
+
+			var b strings.Builder
+
+			for _, file := range files {
+				stamp, err := statStamp(fsys, file)
+				if err != nil {
+					return "", err
+				}
+
+				b.WriteString(file + ":" + stamp + ";")
+			}
+
+			return b.String(), nil
+		},
+		func() (string, error) {
+			files, err := walkFiles(fsys, dir)
+			if err != nil {
+				return "", err
+			}
+
+			return hashFiles(fsys, files)
+		},
+		len(reload) > 0 && reload[0],
+	)
+}
+
+func newVersionCache(stamp, hash func() (string, error), reload bool) (VersionFunc, error) {
+	c := &versionCache{
+		reload: reload,
+		stamp:  stamp,
+		hash:   hash,
+	}
+
+	err := c.refresh()
+	if err != nil {
+		return nil, err
+	}
+
+	c.checked = time.Now()
+
+	return c.resolve, nil
+}
+
+func (c *versionCache) resolve(_ *http.Request) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.reload && time.Since(c.checked) >= versionReloadInterval {
+		c.checked = time.Now()
+
+		// Keep serving the last known version if the files are
+		// temporarily unreadable, e.g. during a rebuild.
+		_ = c.refreshLocked()
+	}
+
+	return c.version
+}
+
+func (c *versionCache) refresh() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.refreshLocked()
+}
+
+func (c *versionCache) refreshLocked() error {
+	current, err := c.stamp()
+	if err != nil {
+		return err
+	}
+
+	if c.version != "" && current == c.current {
+		return nil
+	}
+
+	version, err := c.hash()
+	if err != nil {
+		return err
+	}
+
+	c.current = current
+	c.version = version
+
+	return nil
+}
+
+func statStamp(fsys fs.FS, name string) (string, error) {
+	info, err := fs.Stat(fsys, name)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%d-%d", info.ModTime().UnixNano(), info.Size()), nil
+}
+
+func walkFiles(fsys fs.FS, dir string) ([]string, error) {
+	var files []string
+
+	err := fs.WalkDir(fsys, dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() {
+			files = append(files, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+func hashFiles(fsys fs.FS, files []string) (string, error) {
+	h := sha256.New()
+
+	for _, file := range files {
+		f, err := fsys.Open(file)
+		if err != nil {
+			return "", err
+		}
+
+		io.WriteString(h, file)
+
+		_, err = io.Copy(h, f)
+		f.Close()
+
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
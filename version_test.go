@@ -0,0 +1,155 @@
+package inertia
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestManifestVersion(t *testing.T) {
+	fsys := fstest.MapFS{
+		"build/manifest.json": {Data: []byte(`{"app.js":{"file":"app-1.js"}}`)},
+	}
+
+	versionFunc, err := ManifestVersion(fsys, "build/manifest.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	version := versionFunc(r)
+
+	if len(version) != 64 {
+		t.Errorf("expected sha256 hex version, got: %s", version)
+	}
+
+	fsys["build/manifest.json"] = &fstest.MapFile{Data: []byte(`{"app.js":{"file":"app-2.js"}}`), ModTime: time.Now()}
+
+	if versionFunc(r) != version {
+		t.Error("expected cached version without reload")
+	}
+}
+
+func disableVersionReloadInterval(t *testing.T) {
+	t.Helper()
+
+	interval := versionReloadInterval
+	versionReloadInterval = 0
+
+	t.Cleanup(func() {
+		versionReloadInterval = interval
+	})
+}
+
+func TestManifestVersionWithReload(t *testing.T) {
+	disableVersionReloadInterval(t)
+
+	fsys := fstest.MapFS{
+		"build/manifest.json": {Data: []byte(`{"app.js":{"file":"app-1.js"}}`)},
+	}
+
+	versionFunc, err := ManifestVersion(fsys, "build/manifest.json", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	version := versionFunc(r)
+
+	fsys["build/manifest.json"] = &fstest.MapFile{Data: []byte(`{"app.js":{"file":"app-2.js"}}`), ModTime: time.Now()}
+
+	if versionFunc(r) == version {
+		t.Error("expected version to change after reload")
+	}
+
+	delete(fsys, "build/manifest.json")
+
+	if versionFunc(r) == "" {
+		t.Error("expected last known version when the manifest is missing")
+	}
+}
+
+func TestManifestVersionWithMissingFile(t *testing.T) {
+	_, err := ManifestVersion(fstest.MapFS{}, "build/manifest.json")
+	if err == nil {
+		t.Error("expected error for missing manifest")
+	}
+}
+
+func TestDirVersion(t *testing.T) {
+	disableVersionReloadInterval(t)
+
+	fsys := fstest.MapFS{
+		"build/app.js":  {Data: []byte("console.log(1)")},
+		"build/app.css": {Data: []byte("body{}")},
+	}
+
+	versionFunc, err := DirVersion(fsys, "build", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	version := versionFunc(r)
+
+	fsys["build/vendor.js"] = &fstest.MapFile{Data: []byte("console.log(2)")}
+
+	if versionFunc(r) == version {
+		t.Error("expected version to change after a new file")
+	}
+}
+
+func TestDirVersionReloadInterval(t *testing.T) {
+	fsys := fstest.MapFS{
+		"build/app.js": {Data: []byte("console.log(1)")},
+	}
+
+	versionFunc, err := DirVersion(fsys, "build", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	version := versionFunc(r)
+
+	fsys["build/vendor.js"] = &fstest.MapFile{Data: []byte("console.log(2)")}
+
+	if versionFunc(r) != version {
+		t.Error("expected the cached version within the reload interval")
+	}
+}
+
+func TestMiddlewareWithVersionFunc(t *testing.T) {
+	i := New("http://inertia-go.test", "", "static")
+	i.SetVersionFunc(func(r *http.Request) string {
+		return "dynamic"
+	})
+
+	m := i.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(HeaderInertia, "true")
+	r.Header.Set(HeaderVersion, "static")
+	w := httptest.NewRecorder()
+
+	m.ServeHTTP(w, r)
+
+	if w.Result().StatusCode != http.StatusConflict {
+		t.Errorf("expected status code: %d, got: %d", http.StatusConflict, w.Result().StatusCode)
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(HeaderInertia, "true")
+	r.Header.Set(HeaderVersion, "dynamic")
+	w = httptest.NewRecorder()
+
+	m.ServeHTTP(w, r)
+
+	if w.Result().StatusCode != http.StatusOK {
+		t.Errorf("expected status code: %d, got: %d", http.StatusOK, w.Result().StatusCode)
+	}
+}
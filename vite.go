@@ -0,0 +1,385 @@
+package inertia
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"strings"
+	"sync"
+)
+
+// ViteConfig type.
+type ViteConfig struct {
+	ManifestPath       string
+	BuildURL           string
+	HotFile            string
+	ComponentPrefix    string
+	ComponentExtension string
+}
+
+// ViteChunk type.
+type ViteChunk struct {
+	File    string   `json:"file"`
+	Src     string   `json:"src,omitempty"`
+	IsEntry bool     `json:"isEntry,omitempty"`
+	Imports []string `json:"imports,omitempty"`
+	CSS     []string `json:"css,omitempty"`
+}
+
+// Vite type.
+type Vite struct {
+	mu       sync.Mutex
+	config   ViteConfig
+	fsys     fs.FS
+	manifest map[string]ViteChunk
+}
+
+type viteAssets struct {
+	styles   []string
+	preloads []string
+	scripts  []string
+	seen     map[string]struct{}
+}
+
+// NewVite function.
+func NewVite(config ViteConfig, fsys ...fs.FS) *Vite {
+	if config.ManifestPath == "" {
+		config.ManifestPath = "public/build/.vite/manifest.json"
+	}
+
+	if config.BuildURL == "" {
+		config.BuildURL = "/build/"
+	}
+
+	if config.HotFile == "" {
+		config.HotFile = "public/hot"
+	}
+
+	if config.ComponentPrefix == "" {
+		config.ComponentPrefix = "resources/js/Pages/"
+	}
+
+	if config.ComponentExtension == "" {
+		config.ComponentExtension = ".vue"
+	}
+
+	v := &Vite{
+		config: config,
+	}
+
+	if len(fsys) > 0 && fsys[0] != nil {
+		v.fsys = fsys[0]
+	}
+
+	return v
+}
+
+// IsHot function.
+func (v *Vite) IsHot() bool {
+	_, err := os.Stat(v.config.HotFile)
+
+	return err == nil
+}
+
+// Tags function.
+func (v *Vite) Tags(entries ...string) (template.HTML, error) {
+	var b strings.Builder
+
+	if v.IsHot() {
+		hotURL, err := v.hotURL()
+		if err != nil {
+			return "", err
+		}
+
+		writeScriptTag(&b, hotURL+"/@vite/client")
+
+		for _, entry := range entries {
+			if isCSSPath(entry) {
+				writeLinkTag(&b, "stylesheet", hotURL+"/"+entry)
+			} else {
+				writeScriptTag(&b, hotURL+"/"+entry)
+			}
+		}
+
+		return template.HTML(b.String()), nil
+	}
+
+	assets, err := v.collect(entries...)
+	if err != nil {
+		return "", err
+	}
+
+	for _, style := range assets.styles {
+		writeLinkTag(&b, "stylesheet", style)
+	}
+
+	for _, preload := range assets.preloads {
+		writeLinkTag(&b, "modulepreload", preload)
+	}
+
+	for _, script := range assets.scripts {
+		writeScriptTag(&b, script)
+	}
+
+	return template.HTML(b.String()), nil
+}
+
+// ComponentTags function.
+func (v *Vite) ComponentTags(component string) (template.HTML, error) {
+	if v.IsHot() {
+		return "", nil
+	}
+
+	assets, err := v.collectComponent(component)
+	if err != nil || assets == nil {
+		return "", err
+	}
+
+	var b strings.Builder
+
+	for _, style := range assets.styles {
+		writeLinkTag(&b, "stylesheet", style)
+	}
+
+	for _, preload := range assets.preloads {
+		writeLinkTag(&b, "modulepreload", preload)
+	}
+
+	return template.HTML(b.String()), nil
+}
+
+// Asset function.
+func (v *Vite) Asset(name string) (string, error) {
+	if v.IsHot() {
+		hotURL, err := v.hotURL()
+		if err != nil {
+			return "", err
+		}
+
+		return hotURL + "/" + name, nil
+	}
+
+	manifest, err := v.loadManifest()
+	if err != nil {
+		return "", err
+	}
+
+	chunk, ok := manifest[name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrViteChunkNotFound, name)
+	}
+
+	return v.buildURL(chunk.File), nil
+}
+
+// EnableVite function.
+func (i *Inertia) EnableVite(config ViteConfig) *Vite {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	c := i.snapshot()
+	v := NewVite(config, c.templateFS)
+
+	c.sharedFuncMap = maps.Clone(c.sharedFuncMap)
+	c.sharedFuncMap["vite"] = v.Tags
+	c.sharedFuncMap["viteAsset"] = v.Asset
+	c.sharedFuncMap["viteComponent"] = v.ComponentTags
+	c.parsedTemplate = &parsedTemplate{}
+
+	i.vite = v
+	i.config.Store(c)
+
+	return v
+}
+
+func (v *Vite) preloadLinks(component string, entries ...string) ([]string, error) {
+	if v.IsHot() {
+		return nil, nil
+	}
+
+	assets, err := v.collect(entries...)
+	if err != nil {
+		return nil, err
+	}
+
+	componentAssets, err := v.collectComponent(component)
+	if err != nil {
+		return nil, err
+	}
+
+	if componentAssets != nil {
+		for _, style := range componentAssets.styles {
+			assets.add(&assets.styles, style)
+		}
+
+		for _, preload := range componentAssets.preloads {
+			assets.add(&assets.preloads, preload)
+		}
+	}
+
+	var links []string
+
+	for _, style := range assets.styles {
+		links = append(links, preloadLink(style))
+	}
+
+	for _, script := range assets.scripts {
+		links = append(links, preloadLink(script))
+	}
+
+	for _, preload := range assets.preloads {
+		links = append(links, preloadLink(preload))
+	}
+
+	return links, nil
+}
+
+func (v *Vite) hotURL() (string, error) {
+	content, err := os.ReadFile(v.config.HotFile)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(strings.TrimSpace(string(content)), "/"), nil
+}
+
+func (v *Vite) loadManifest() (map[string]ViteChunk, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.manifest != nil {
+		return v.manifest, nil
+	}
+
+	var content []byte
+	var err error
+
+	if v.fsys != nil {
+		content, err = fs.ReadFile(v.fsys, v.config.ManifestPath)
+	} else {
+		content, err = os.ReadFile(v.config.ManifestPath)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest map[string]ViteChunk
+
+	err = json.Unmarshal(content, &manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	v.manifest = manifest
+
+	return v.manifest, nil
+}
+
+func (v *Vite) collect(entries ...string) (*viteAssets, error) {
+	manifest, err := v.loadManifest()
+	if err != nil {
+		return nil, err
+	}
+
+	assets := &viteAssets{
+		seen: make(map[string]struct{}),
+	}
+
+	for _, entry := range entries {
+		chunk, ok := manifest[entry]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrViteChunkNotFound, entry)
+		}
+
+		v.collectImports(manifest, chunk, assets)
+
+		if isCSSPath(chunk.File) {
+			assets.add(&assets.styles, v.buildURL(chunk.File))
+		} else {
+			assets.add(&assets.scripts, v.buildURL(chunk.File))
+		}
+	}
+
+	return assets, nil
+}
+
+func (v *Vite) collectComponent(component string) (*viteAssets, error) {
+	manifest, err := v.loadManifest()
+	if err != nil {
+		return nil, err
+	}
+
+	// Pages bundled into the entry chunk have no chunk of their own,
+	// there is nothing extra to preload for them.
+	chunk, ok := manifest[v.componentPath(component)]
+	if !ok {
+		return nil, nil
+	}
+
+	assets := &viteAssets{
+		seen: make(map[string]struct{}),
+	}
+
+	assets.add(&assets.preloads, v.buildURL(chunk.File))
+	v.collectImports(manifest, chunk, assets)
+
+	return assets, nil
+}
+
+func (v *Vite) componentPath(component string) string {
+	return v.config.ComponentPrefix + component + v.config.ComponentExtension
+}
+
+func (v *Vite) collectImports(manifest map[string]ViteChunk, chunk ViteChunk, assets *viteAssets) {
+	for _, css := range chunk.CSS {
+		assets.add(&assets.styles, v.buildURL(css))
+	}
+
+	for _, name := range chunk.Imports {
+		imported, ok := manifest[name]
+		if !ok {
+			continue
+		}
+
+		if assets.add(&assets.preloads, v.buildURL(imported.File)) {
+			v.collectImports(manifest, imported, assets)
+		}
+	}
+}
+
+func (v *Vite) buildURL(file string) string {
+	return strings.TrimSuffix(v.config.BuildURL, "/") + "/" + file
+}
+
+func (a *viteAssets) add(list *[]string, url string) bool {
+	if _, ok := a.seen[url]; ok {
+		return false
+	}
+
+	a.seen[url] = struct{}{}
+	*list = append(*list, url)
+
+	return true
+}
+
+func isCSSPath(name string) bool {
+	switch path.Ext(name) {
+	case ".css", ".less", ".sass", ".scss", ".styl", ".stylus", ".pcss", ".postcss":
+		return true
+	}
+
+	return false
+}
+
+func writeScriptTag(b *strings.Builder, src string) {
+	b.WriteString(`<script type="module" src="` + template.HTMLEscapeString(src) + `"></script>` + "\n")
+}
+
+func writeLinkTag(b *strings.Builder, rel, href string) {
+	b.WriteString(`<link rel="` + rel + `" href="` + template.HTMLEscapeString(href) + `">` + "\n")
+}
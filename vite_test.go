@@ -0,0 +1,199 @@
+package inertia
+
+import (
+	"errors"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+const testViteManifest = `{
+	"resources/js/app.js": {
+		"file": "assets/app-4ed993c7.js",
+		"src": "resources/js/app.js",
+		"isEntry": true,
+		"imports": ["_vendor-9a8b7c6d.js"],
+		"css": ["assets/app-7d8b5a3f.css"]
+	},
+	"_vendor-9a8b7c6d.js": {
+		"file": "assets/vendor-9a8b7c6d.js",
+		"css": ["assets/vendor-1a2b3c4d.css"]
+	},
+	"resources/js/Pages/Users/Index.vue": {
+		"file": "assets/Index-5e6f7a8b.js",
+		"src": "resources/js/Pages/Users/Index.vue",
+		"imports": ["_vendor-9a8b7c6d.js"],
+		"css": ["assets/Index-3c4d5e6f.css"]
+	},
+	"resources/css/admin.css": {
+		"file": "assets/admin-0f1e2d3c.css",
+		"src": "resources/css/admin.css",
+		"isEntry": true
+	}
+}`
+
+func newTestVite(t *testing.T) *Vite {
+	t.Helper()
+
+	fsys := fstest.MapFS{
+		"public/build/.vite/manifest.json": {Data: []byte(testViteManifest)},
+	}
+
+	return NewVite(ViteConfig{
+		HotFile: filepath.Join(t.TempDir(), "hot"),
+	}, fsys)
+}
+
+func TestViteTags(t *testing.T) {
+	v := newTestVite(t)
+
+	got, err := v.Tags("resources/js/app.js", "resources/css/admin.css")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := template.HTML(`<link rel="stylesheet" href="/build/assets/app-7d8b5a3f.css">
+<link rel="stylesheet" href="/build/assets/vendor-1a2b3c4d.css">
+<link rel="stylesheet" href="/build/assets/admin-0f1e2d3c.css">
+<link rel="modulepreload" href="/build/assets/vendor-9a8b7c6d.js">
+<script type="module" src="/build/assets/app-4ed993c7.js"></script>
+`)
+
+	if got != expected {
+		t.Errorf("expected: %s, got: %s", expected, got)
+	}
+}
+
+func TestViteTagsWithMissingEntry(t *testing.T) {
+	v := newTestVite(t)
+
+	_, err := v.Tags("resources/js/missing.js")
+	if !errors.Is(err, ErrViteChunkNotFound) {
+		t.Errorf("expected: %v, got: %v", ErrViteChunkNotFound, err)
+	}
+}
+
+func TestViteTagsWithHotFile(t *testing.T) {
+	v := newTestVite(t)
+
+	err := os.WriteFile(v.config.HotFile, []byte("http://localhost:5173\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !v.IsHot() {
+		t.Error("expected: true, got: false")
+	}
+
+	got, err := v.Tags("resources/js/app.js", "resources/css/admin.css")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := template.HTML(`<script type="module" src="http://localhost:5173/@vite/client"></script>
+<script type="module" src="http://localhost:5173/resources/js/app.js"></script>
+<link rel="stylesheet" href="http://localhost:5173/resources/css/admin.css">
+`)
+
+	if got != expected {
+		t.Errorf("expected: %s, got: %s", expected, got)
+	}
+}
+
+func TestViteComponentTags(t *testing.T) {
+	v := newTestVite(t)
+
+	got, err := v.ComponentTags("Users/Index")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := template.HTML(`<link rel="stylesheet" href="/build/assets/Index-3c4d5e6f.css">
+<link rel="stylesheet" href="/build/assets/vendor-1a2b3c4d.css">
+<link rel="modulepreload" href="/build/assets/Index-5e6f7a8b.js">
+<link rel="modulepreload" href="/build/assets/vendor-9a8b7c6d.js">
+`)
+
+	if got != expected {
+		t.Errorf("expected: %s, got: %s", expected, got)
+	}
+
+	got, err = v.ComponentTags("Users/Missing")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != "" {
+		t.Errorf("expected empty tags, got: %s", got)
+	}
+}
+
+func TestViteComponentTagsWithCustomMapping(t *testing.T) {
+	fsys := fstest.MapFS{
+		"public/build/.vite/manifest.json": {Data: []byte(`{
+			"src/pages/Users/Index.tsx": {"file": "assets/Index-1a2b.js"}
+		}`)},
+	}
+
+	v := NewVite(ViteConfig{
+		HotFile:            filepath.Join(t.TempDir(), "hot"),
+		ComponentPrefix:    "src/pages/",
+		ComponentExtension: ".tsx",
+	}, fsys)
+
+	got, err := v.ComponentTags("Users/Index")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := template.HTML(`<link rel="modulepreload" href="/build/assets/Index-1a2b.js">
+`)
+
+	if got != expected {
+		t.Errorf("expected: %s, got: %s", expected, got)
+	}
+}
+
+func TestViteAsset(t *testing.T) {
+	v := newTestVite(t)
+
+	got, err := v.Asset("resources/css/admin.css")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != "/build/assets/admin-0f1e2d3c.css" {
+		t.Errorf("expected: /build/assets/admin-0f1e2d3c.css, got: %s", got)
+	}
+}
+
+func TestEnableVite(t *testing.T) {
+	templateFS := fstest.MapFS{
+		"public/build/.vite/manifest.json": {Data: []byte(testViteManifest)},
+		"app.gohtml":                       {Data: []byte(`{{ vite "resources/js/app.js" }}`)},
+	}
+
+	i := New("http://inertia-go.test", "app.gohtml", "", templateFS)
+	i.EnableVite(ViteConfig{
+		HotFile: filepath.Join(t.TempDir(), "hot"),
+	})
+
+	tpl, err := i.config.Load().createRootTemplate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b strings.Builder
+
+	err = tpl.Execute(&b, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(b.String(), `<script type="module" src="/build/assets/app-4ed993c7.js"></script>`) {
+		t.Errorf("expected app script tag, got: %s", b.String())
+	}
+}
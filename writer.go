@@ -0,0 +1,88 @@
+package inertia
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+)
+
+type responseWriter struct {
+	http.ResponseWriter
+	request   *http.Request
+	session   *session
+	committed bool
+}
+
+func newResponseWriter(w http.ResponseWriter, r *http.Request, sess *session) *responseWriter {
+	return &responseWriter{
+		ResponseWriter: w,
+		request:        r,
+		session:        sess,
+	}
+}
+
+// WriteHeader function.
+func (w *responseWriter) WriteHeader(statusCode int) {
+	if statusCode == http.StatusFound && w.request.Header.Get(HeaderInertia) != "" {
+		switch w.request.Method {
+		case http.MethodPut, http.MethodPatch, http.MethodDelete:
+			statusCode = http.StatusSeeOther
+		}
+	}
+
+	if statusCode >= http.StatusOK {
+		w.commit()
+	}
+
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write function.
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.commit()
+
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush function.
+func (w *responseWriter) Flush() {
+	w.commit()
+
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Hijack function.
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.commit()
+
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+// ReadFrom function.
+func (w *responseWriter) ReadFrom(src io.Reader) (int64, error) {
+	w.commit()
+
+	if rf, ok := w.ResponseWriter.(io.ReaderFrom); ok {
+		return rf.ReadFrom(src)
+	}
+
+	return io.Copy(w.ResponseWriter, src)
+}
+
+// Unwrap function.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *responseWriter) commit() {
+	if w.committed {
+		return
+	}
+
+	w.committed = true
+
+	if w.session != nil {
+		w.session.commit(w.ResponseWriter, w.request)
+	}
+}